@@ -0,0 +1,234 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// healAmounts is how much HP each healing item restores, -1 heals fully
+var healAmounts = map[string]int{
+	"potion":       20,
+	"super-potion": 60,
+	"hyper-potion": 120,
+	"fresh-water":  30,
+	"soda-pop":     50,
+	"lemonade":     70,
+	"moomoo-milk":  100,
+	"max-potion":   -1,
+	"full-restore": -1,
+}
+
+func startingBag() map[string]int {
+	return map[string]int{
+		"potion":       5,
+		"super-potion": 2,
+		"rare-candy":   1,
+	}
+}
+
+func addToBag(param *config, item string) {
+	if param.Bag == nil {
+		param.Bag = make(map[string]int)
+	}
+	param.Bag[item]++
+}
+
+func removeFromBag(param *config, item string) {
+	param.Bag[item]--
+	if param.Bag[item] <= 0 {
+		delete(param.Bag, item)
+	}
+}
+
+// rollHeldItem gives a wild pokemon one of the items it can hold in a
+// version, each with its rarity as a percent chance. An empty version
+// uses the rarity from any game.
+func rollHeldItem(pokemonData PokeData, version string, r roller) string {
+	for _, held := range pokemonData.HeldItems {
+		for _, detail := range held.VersionDetails {
+			if version != "" && detail.Version.Name != version {
+				continue
+			}
+			if r.roll(100) < detail.Rarity {
+				return held.Item.Name
+			}
+			break
+		}
+	}
+	return ""
+}
+
+func commandBag(param *config) error {
+	fmt.Println("Poké Balls:")
+	for _, key := range ballOrder {
+		if param.Inventory[key] > 0 {
+			fmt.Printf(" - %v-ball x%d\n", key, param.Inventory[key])
+		}
+	}
+
+	fmt.Println("Items:")
+	if len(param.Bag) == 0 {
+		fmt.Println(" - (empty)")
+		return nil
+	}
+
+	names := []string{}
+	for name := range param.Bag {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		item, err := fetchItem(param, name)
+		if err != nil {
+			fmt.Println("Error loading the item from pokeAPI")
+			return err
+		}
+
+		fmt.Printf(" - %v x%d\n", item.displayName(), param.Bag[name])
+		if description := item.description(); description != "" {
+			fmt.Printf("     %v\n", description)
+		}
+	}
+	return nil
+}
+
+func commandGive(param *config) error {
+	if len(param.Args) < 2 {
+		fmt.Println("Usage: give <item> <pokemon>")
+		return nil
+	}
+
+	item := param.Args[0]
+	if param.Bag[item] <= 0 {
+		fmt.Printf("You don't have any %v in your bag\n", item)
+		return nil
+	}
+
+	pokemon, err := findCaught(param, param.Args[1])
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+
+	if _, err := fetchItem(param, item); err != nil {
+		fmt.Println("Error loading the item from pokeAPI")
+		return err
+	}
+
+	if pokemon.HeldItem != "" {
+		addToBag(param, pokemon.HeldItem)
+		fmt.Printf("Took the %v from %v and put it in your bag\n", pokemon.HeldItem, pokemon.displayName())
+	}
+
+	removeFromBag(param, item)
+	pokemon.HeldItem = item
+	fmt.Printf("%v is now holding %v\n", pokemon.displayName(), item)
+	return nil
+}
+
+func commandTake(param *config) error {
+	if len(param.Args) == 0 {
+		fmt.Println("Usage: take <pokemon>")
+		return nil
+	}
+
+	pokemon, err := findCaught(param, param.Args[0])
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+
+	if pokemon.HeldItem == "" {
+		fmt.Printf("%v isn't holding anything\n", pokemon.displayName())
+		return nil
+	}
+
+	addToBag(param, pokemon.HeldItem)
+	fmt.Printf("Took the %v from %v\n", pokemon.HeldItem, pokemon.displayName())
+	pokemon.HeldItem = ""
+	return nil
+}
+
+func commandUse(param *config) error {
+	if len(param.Args) == 0 {
+		fmt.Println("Usage: use <item> [pokemon]")
+		return nil
+	}
+
+	name := param.Args[0]
+	if param.Bag[name] <= 0 {
+		fmt.Printf("You don't have any %v in your bag\n", name)
+		return nil
+	}
+
+	item, err := fetchItem(param, name)
+	if err != nil {
+		fmt.Println("Error loading the item from pokeAPI")
+		return err
+	}
+
+	if heal, ok := healAmounts[name]; ok {
+		return useHealingItem(param, item, heal)
+	}
+
+	if len(param.Args) < 2 {
+		fmt.Printf("Who should the %v be used on?\n", item.displayName())
+		return nil
+	}
+	pokemon, err := findCaught(param, param.Args[1])
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+
+	switch {
+	case name == "rare-candy":
+		if pokemon.Level >= maxLevel {
+			fmt.Println("It won't have any effect.")
+			return nil
+		}
+		growth, err := growthRate(param, pokemon)
+		if err != nil {
+			fmt.Println("Error loading the growth rate")
+			return err
+		}
+		removeFromBag(param, name)
+		needed := growth.experienceForLevel(pokemon.Level+1) - max(pokemon.Experience, growth.experienceForLevel(pokemon.Level))
+		return gainExperience(param, pokemon, needed, nil)
+
+	case item.Category.Name == "evolution":
+		_, err := tryEvolve(param, pokemon, evolveAttempt{Item: name})
+		return err
+	}
+
+	fmt.Printf("%v: %v\n", item.displayName(), item.description())
+	fmt.Println("It won't have any effect.")
+	return nil
+}
+
+// useHealingItem restores HP to your pokemon in battle, which takes up your turn
+func useHealingItem(param *config, item PokeItem, heal int) error {
+	battle := param.Battle
+	if battle == nil {
+		fmt.Println("Your pokemon are at full health outside of battle")
+		return nil
+	}
+
+	player := battle.Player
+	if player.HP == player.MaxHP {
+		fmt.Println("It won't have any effect.")
+		return nil
+	}
+
+	before := player.HP
+	if heal < 0 {
+		player.HP = player.MaxHP
+	} else {
+		player.HP = min(player.MaxHP, player.HP+heal)
+	}
+	removeFromBag(param, item.Name)
+	fmt.Printf("Used a %v! %v recovered %d HP\n", item.displayName(), player.Name, player.HP-before)
+
+	return finishTurn(param, battle.opponentTurn(nil))
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRollHeldItem(t *testing.T) {
+	var pokemonData PokeData
+	fixture := `{"held_items": [
+		{"item": {"name": "oran-berry"}, "version_details": [
+			{"rarity": 50, "version": {"name": "ruby"}},
+			{"rarity": 5, "version": {"name": "emerald"}}
+		]},
+		{"item": {"name": "sitrus-berry"}, "version_details": [
+			{"rarity": 5, "version": {"name": "ruby"}}
+		]}
+	]}`
+	if err := json.Unmarshal([]byte(fixture), &pokemonData); err != nil {
+		t.Fatalf("could not decode fixture: %v", err)
+	}
+
+	cases := []struct {
+		version  string
+		roll     int
+		expected string
+	}{
+		{version: "ruby", roll: 0, expected: "oran-berry"},
+		{version: "ruby", roll: 49, expected: "oran-berry"},
+		{version: "ruby", roll: 50, expected: ""},
+		{version: "emerald", roll: 4, expected: "oran-berry"},
+		{version: "emerald", roll: 10, expected: ""},
+		{version: "red", roll: 0, expected: ""},
+		{version: "", roll: 20, expected: "oran-berry"},
+	}
+
+	for _, c := range cases {
+		roll := c.roll
+		actual := rollHeldItem(pokemonData, c.version, func(n int) int { return roll })
+		if actual != c.expected {
+			t.Errorf("In %q with roll %d, expected %q but got %q", c.version, c.roll, c.expected, actual)
+		}
+	}
+}
+
+func TestBagAddRemove(t *testing.T) {
+	param := &config{}
+
+	addToBag(param, "potion")
+	addToBag(param, "potion")
+	removeFromBag(param, "potion")
+	if param.Bag["potion"] != 1 {
+		t.Errorf("expected 1 potion but got %d", param.Bag["potion"])
+	}
+
+	removeFromBag(param, "potion")
+	if _, ok := param.Bag["potion"]; ok {
+		t.Errorf("expected the last potion to leave the bag")
+	}
+}
@@ -0,0 +1,419 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// At most this many level-up moves are looked up when picking a battle moveset
+const maxMoveLookups = 10
+
+// Battler is one side of a battle
+type Battler struct {
+	Name    string
+	Level   int
+	Types   []string
+	Stats   map[string]int
+	HP      int
+	MaxHP   int
+	Moves   []PokeMove
+	PP      []int          // PP left for each of Moves
+	Pokemon *CaughtPokemon // nil for wild pokemon
+	Data    PokeData
+}
+
+type Battle struct {
+	Player   *Battler
+	Opponent *Battler
+	Wild     *WildPokemon // Set when fighting the wild pokemon from the last encounter
+	Chart    *TypeChart
+}
+
+func (b *Battler) fainted() bool {
+	return b.HP <= 0
+}
+
+func (b *Battler) status() string {
+	return fmt.Sprintf("%v Lv%d HP %d/%d", b.Name, b.Level, b.HP, b.MaxHP)
+}
+
+func fetchPokemon(param *config, name string) (PokeData, error) {
+	var pokemonData PokeData
+
+	body, err := fetchData(param, fmt.Sprintf("https://pokeapi.co/api/v2/pokemon/%v", name))
+	if err != nil {
+		return pokemonData, err
+	}
+
+	if err := json.Unmarshal(body, &pokemonData); err != nil {
+		return pokemonData, err
+	}
+	return pokemonData, nil
+}
+
+// battleMoves picks up to four damaging moves the pokemon knows at its level
+func battleMoves(param *config, pokemonData PokeData, level int) []PokeMove {
+	moves := []PokeMove{}
+
+	for i, learned := range levelUpMoves(pokemonData, level, param.VersionGroup) {
+		if len(moves) == 4 || i == maxMoveLookups {
+			break
+		}
+
+		move, err := fetchMove(param, learned.Name)
+		if err != nil || move.Power == 0 {
+			continue
+		}
+		moves = append(moves, move)
+	}
+
+	if len(moves) == 0 {
+		moves = append(moves, struggle)
+	}
+	return moves
+}
+
+func battlerFromSpecies(name string, pokemonData PokeData, level int) *Battler {
+	stats := levelStats(pokemonData, level)
+
+	return &Battler{
+		Name:  name,
+		Level: level,
+		Types: savePokemon(pokemonData).Types,
+		Stats: stats,
+		HP:    stats["hp"],
+		MaxHP: stats["hp"],
+		Data:  pokemonData,
+	}
+}
+
+func battlerFromCaught(pokemon *CaughtPokemon) *Battler {
+	return &Battler{
+		Name:    pokemon.displayName(),
+		Level:   pokemon.Level,
+		Types:   savePokemon(pokemon.Data).Types,
+		Stats:   pokemon.Stats,
+		HP:      pokemon.Stats["hp"],
+		MaxHP:   pokemon.Stats["hp"],
+		Pokemon: pokemon,
+		Data:    pokemon.Data,
+	}
+}
+
+// knownMoves loads the moveset of a caught pokemon, falling back to
+// battleMoves for pokemon that don't have one yet
+func knownMoves(param *config, pokemon *CaughtPokemon) []PokeMove {
+	moves := []PokeMove{}
+	for _, name := range pokemon.Moves {
+		if move, err := fetchMove(param, name); err == nil {
+			moves = append(moves, move)
+		}
+	}
+
+	if len(moves) == 0 {
+		return battleMoves(param, pokemon.Data, pokemon.Level)
+	}
+	return moves
+}
+
+// learnMoves gives a battler its moveset with full PP
+func (b *Battler) learnMoves(moves []PokeMove) {
+	b.Moves = moves
+	b.PP = make([]int, len(moves))
+	for i, move := range moves {
+		b.PP[i] = move.PP
+	}
+}
+
+// useMove spends a PP on move i, falling back to struggle when i is out of range
+func (b *Battler) useMove(i int) PokeMove {
+	if i < 0 || i >= len(b.Moves) || b.Moves[i].Name == struggle.Name {
+		return struggle
+	}
+	b.PP[i]--
+	return b.Moves[i]
+}
+
+// movesWithPP lists the indexes of moves that can still be used
+func (b *Battler) movesWithPP() []int {
+	usable := []int{}
+	for i, move := range b.Moves {
+		if b.PP[i] > 0 || move.Name == struggle.Name {
+			usable = append(usable, i)
+		}
+	}
+	return usable
+}
+
+// battleDamage rolls the random factor for calcDamage
+func battleDamage(attacker, defender *Battler, move PokeMove, chart *TypeChart, r roller) int {
+	return calcDamage(attacker, defender, move, chart, 85+r.roll(16))
+}
+
+// movesFirst reports whether a acts before b: higher move priority wins, then
+// higher speed, with speed ties decided by a coin flip.
+func movesFirst(a, b *Battler, aMove, bMove PokeMove, r roller) bool {
+	if aMove.Priority != bMove.Priority {
+		return aMove.Priority > bMove.Priority
+	}
+	if a.Stats["speed"] != b.Stats["speed"] {
+		return a.Stats["speed"] > b.Stats["speed"]
+	}
+	return r.roll(2) == 0
+}
+
+func attack(attacker, defender *Battler, move PokeMove, chart *TypeChart, r roller) {
+	if move.Accuracy > 0 && r.roll(100) >= move.Accuracy {
+		fmt.Printf("%v used %v! It missed\n", attacker.Name, move.Name)
+		return
+	}
+	if move.Power == 0 {
+		fmt.Printf("%v used %v! But nothing happened\n", attacker.Name, move.Name)
+		return
+	}
+
+	damage := battleDamage(attacker, defender, move, chart, r)
+	defender.HP = int(math.Max(0, float64(defender.HP-damage)))
+	fmt.Printf("%v used %v! It dealt %d damage\n", attacker.Name, move.Name, damage)
+
+	switch effectiveness := chart.effectiveness(move.Type.Name, defender.Types); {
+	case effectiveness == 0:
+		fmt.Printf("It doesn't affect %v...\n", defender.Name)
+	case effectiveness > 1:
+		fmt.Println("It's super effective!")
+	case effectiveness < 1:
+		fmt.Println("It's not very effective...")
+	}
+}
+
+// playTurn runs one round with the player's move i and returns the battler
+// that fainted, if any
+func (b *Battle) playTurn(i int, r roller) *Battler {
+	playerMove := b.Player.useMove(i)
+	opponentMove := b.opponentMove(r)
+
+	first, second := b.Player, b.Opponent
+	firstMove, secondMove := playerMove, opponentMove
+	if !movesFirst(b.Player, b.Opponent, playerMove, opponentMove, r) {
+		first, second = second, first
+		firstMove, secondMove = secondMove, firstMove
+	}
+
+	attack(first, second, firstMove, b.Chart, r)
+	if second.fainted() {
+		return second
+	}
+
+	attack(second, first, secondMove, b.Chart, r)
+	if first.fainted() {
+		return first
+	}
+	return nil
+}
+
+// opponentMove picks a random move that still has PP for the opponent
+func (b *Battle) opponentMove(r roller) PokeMove {
+	choice := -1
+	if usable := b.Opponent.movesWithPP(); len(usable) > 0 {
+		choice = usable[r.roll(len(usable))]
+	}
+	return b.Opponent.useMove(choice)
+}
+
+// opponentTurn lets only the opponent act, after the player spent the turn
+// on something else. It returns the player's battler if it fainted.
+func (b *Battle) opponentTurn(r roller) *Battler {
+	attack(b.Opponent, b.Player, b.opponentMove(r), b.Chart, r)
+	if b.Player.fainted() {
+		return b.Player
+	}
+	return nil
+}
+
+func printBattle(b *Battle) {
+	fmt.Printf("%v  vs  %v\n", b.Player.status(), b.Opponent.status())
+	fmt.Println("Your moves:")
+	for i, move := range b.Player.Moves {
+		fmt.Printf(" %d. %v (%v, power %d, PP %d/%d)\n", i+1, move.Name, orUnknown(move.Type.Name), move.Power, b.Player.PP[i], move.PP)
+	}
+}
+
+func commandBattle(param *config) error {
+	if param.Battle != nil {
+		printBattle(param.Battle)
+		return nil
+	}
+
+	positional, flags := parseArgs(param.Args)
+
+	if len(param.Party) == 0 {
+		fmt.Println("You don't have any pokemon in your party!")
+		return nil
+	}
+
+	mine := param.Caught[param.Party[0]]
+	if len(positional) > 0 {
+		pokemon, err := findCaught(param, positional[0])
+		if err != nil {
+			fmt.Println(err)
+			return nil
+		}
+		if indexOf(param.Party, pokemon.ID) < 0 {
+			fmt.Printf("%v has to be in your party to battle\n", pokemon.displayName())
+			return nil
+		}
+		mine = pokemon
+	}
+	if err := mine.loadData(param); err != nil {
+		fmt.Println("Error loading the pokemon from pokeAPI")
+		return err
+	}
+
+	chart, err := loadTypeChart(param)
+	if err != nil {
+		fmt.Println("Error loading the type chart")
+		return err
+	}
+
+	battle := &Battle{
+		Player: battlerFromCaught(mine),
+		Chart:  chart,
+	}
+	battle.Player.learnMoves(knownMoves(param, mine))
+
+	if ref, ok := flags["vs"]; ok {
+		theirs, err := findCaught(param, ref)
+		if err != nil {
+			fmt.Println(err)
+			return nil
+		}
+		if theirs == mine {
+			fmt.Println("A pokemon can't battle itself")
+			return nil
+		}
+		if err := theirs.loadData(param); err != nil {
+			fmt.Println("Error loading the pokemon from pokeAPI")
+			return err
+		}
+		battle.Opponent = battlerFromCaught(theirs)
+		battle.Opponent.learnMoves(knownMoves(param, theirs))
+	} else {
+		if param.Wild == nil {
+			fmt.Println("There's no wild pokemon to battle, try walk, fish or surf first")
+			return nil
+		}
+
+		pokemonData, err := fetchPokemon(param, param.Wild.Name)
+		if err != nil {
+			fmt.Println("Error loading the wild pokemon")
+			return err
+		}
+		battle.Wild = param.Wild
+		battle.Opponent = battlerFromSpecies("wild "+param.Wild.Name, pokemonData, param.Wild.Level)
+		battle.Opponent.learnMoves(battleMoves(param, pokemonData, param.Wild.Level))
+		if param.Wild.MaxHP > 0 {
+			battle.Opponent.HP = param.Wild.HP
+		}
+	}
+
+	param.Battle = battle
+	fmt.Println("The battle begins!")
+	printBattle(battle)
+	fmt.Println("Use fight <move> to attack or run to flee")
+	return nil
+}
+
+func commandFight(param *config) error {
+	battle := param.Battle
+	if battle == nil {
+		fmt.Println("You aren't in a battle, start one with battle")
+		return nil
+	}
+	if len(param.Args) == 0 {
+		printBattle(battle)
+		return nil
+	}
+
+	chosen := -1
+	choice := strings.Join(param.Args, "-")
+	if n, err := strconv.Atoi(choice); err == nil && n >= 1 && n <= len(battle.Player.Moves) {
+		chosen = n - 1
+	}
+	for i := range battle.Player.Moves {
+		if battle.Player.Moves[i].Name == choice {
+			chosen = i
+		}
+	}
+	if chosen < 0 {
+		fmt.Printf("%v doesn't know %v\n", battle.Player.Name, choice)
+		return nil
+	}
+
+	usable := battle.Player.movesWithPP()
+	if len(usable) == 0 {
+		fmt.Printf("%v has no PP left and struggles!\n", battle.Player.Name)
+		chosen = -1
+	} else if indexOf(usable, chosen) < 0 {
+		fmt.Printf("There's no PP left for %v!\n", battle.Player.Moves[chosen].Name)
+		return nil
+	}
+
+	return finishTurn(param, battle.playTurn(chosen, nil))
+}
+
+// finishTurn reports the state after a turn and ends the battle when a side fainted
+func finishTurn(param *config, fainted *Battler) error {
+	battle := param.Battle
+
+	if battle.Wild != nil {
+		battle.Wild.HP = battle.Opponent.HP
+		battle.Wild.MaxHP = battle.Opponent.MaxHP
+	}
+
+	switch fainted {
+	case nil:
+		fmt.Printf("%v  vs  %v\n", battle.Player.status(), battle.Opponent.status())
+		if battle.Wild != nil {
+			fmt.Println("The weaker it gets, the easier it is to catch!")
+		}
+	case battle.Opponent:
+		fmt.Printf("%v fainted! You win!\n", battle.Opponent.Name)
+		param.Battle = nil
+		if battle.Wild != nil {
+			param.Wild = nil
+		}
+
+		// Sparring with your own pokemon would be an endless source of experience
+		if battle.Opponent.Pokemon != nil {
+			fmt.Println("Practice battles against your own pokemon don't give experience")
+			return nil
+		}
+
+		experience := experienceYield(battle.Opponent.Data.BaseExperience, battle.Opponent.Level, battle.Wild == nil)
+		if err := gainExperience(param, battle.Player.Pokemon, experience, effortYield(battle.Opponent.Data)); err != nil {
+			fmt.Println("Error loading the growth rate")
+			return err
+		}
+	default:
+		fmt.Printf("%v fainted! You lose...\n", battle.Player.Name)
+		param.Battle = nil
+	}
+	return nil
+}
+
+func commandRun(param *config) error {
+	if param.Battle == nil {
+		fmt.Println("You aren't in a battle")
+		return nil
+	}
+
+	if param.Battle.Wild != nil {
+		param.Wild = nil
+	}
+	param.Battle = nil
+	fmt.Println("Got away safely!")
+	return nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestMovesFirst(t *testing.T) {
+	fast := &Battler{Stats: map[string]int{"speed": 90}}
+	slow := &Battler{Stats: map[string]int{"speed": 45}}
+	tackle := PokeMove{Name: "tackle"}
+	quickAttack := PokeMove{Name: "quick-attack", Priority: 1}
+
+	cases := []struct {
+		name     string
+		a, b     *Battler
+		aMove    PokeMove
+		bMove    PokeMove
+		coin     int
+		expected bool
+	}{
+		{name: "faster goes first", a: fast, b: slow, aMove: tackle, bMove: tackle, expected: true},
+		{name: "slower goes second", a: slow, b: fast, aMove: tackle, bMove: tackle, expected: false},
+		{name: "priority beats speed", a: slow, b: fast, aMove: quickAttack, bMove: tackle, expected: true},
+		{name: "speed tie heads", a: fast, b: fast, aMove: tackle, bMove: tackle, coin: 0, expected: true},
+		{name: "speed tie tails", a: fast, b: fast, aMove: tackle, bMove: tackle, coin: 1, expected: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			coin := c.coin
+			if actual := movesFirst(c.a, c.b, c.aMove, c.bMove, func(n int) int { return coin }); actual != c.expected {
+				t.Errorf("expected %v but got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestBattleDamage(t *testing.T) {
+	attacker := &Battler{Level: 50, Types: []string{"normal"}, Stats: map[string]int{"attack": 100, "special-attack": 50}}
+	defender := &Battler{Stats: map[string]int{"defense": 100, "special-defense": 100}}
+
+	tackle := PokeMove{Power: 40, DamageClass: NamedAPIResource{Name: "physical"}, Type: NamedAPIResource{Name: "normal"}}
+	ember := PokeMove{Power: 40, DamageClass: NamedAPIResource{Name: "special"}, Type: NamedAPIResource{Name: "fire"}}
+
+	cases := []struct {
+		name     string
+		move     PokeMove
+		roll     int
+		expected int
+	}{
+		{name: "max roll with STAB", move: tackle, roll: 15, expected: 28},
+		{name: "min roll with STAB", move: tackle, roll: 0, expected: 24},
+		{name: "special without STAB", move: ember, roll: 15, expected: 10},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			roll := c.roll
+			if actual := battleDamage(attacker, defender, c.move, nil, func(n int) int { return roll }); actual != c.expected {
+				t.Errorf("expected %v but got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPracticeBattleGivesNothing(t *testing.T) {
+	param := &config{}
+	mine := newCaughtPokemon(param, PokeData{Name: "pikachu", BaseExperience: 112}, 5)
+	theirs := newCaughtPokemon(param, PokeData{Name: "eevee", BaseExperience: 65}, 5)
+
+	battle := &Battle{Player: battlerFromCaught(mine), Opponent: battlerFromCaught(theirs)}
+	battle.Opponent.HP = 0
+	param.Battle = battle
+
+	if err := finishTurn(param, battle.Opponent); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if param.Battle != nil {
+		t.Errorf("expected the battle to be over")
+	}
+	if mine.Experience != 0 || len(mine.EVs) != 0 {
+		t.Errorf("expected no experience or EVs from beating your own pokemon, got %d and %v", mine.Experience, mine.EVs)
+	}
+}
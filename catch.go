@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// CatchTarget is everything a CatchModel may look at when a ball is thrown
+type CatchTarget struct {
+	Pokemon     PokeData
+	CaptureRate int     // pokemon-species capture_rate, 3 for legendaries up to 255
+	HPFraction  float64 // current HP / max HP, 1 at full health
+	BallBonus   float64
+}
+
+type CatchResult struct {
+	Caught bool
+	Shakes int
+}
+
+type CatchModel interface {
+	Name() string
+	Attempt(target CatchTarget) CatchResult
+}
+
+var catchModels = map[string]CatchModel{
+	"simple":   SimpleCatchModel{},
+	"standard": StandardCatchModel{},
+}
+
+// roller returns a random number in [0, n), tests swap it for a fixed sequence
+type roller func(n int) int
+
+func (r roller) roll(n int) int {
+	if r == nil {
+		return rand.Intn(n)
+	}
+	return r(n)
+}
+
+// SimpleCatchModel is the original formula: BaseExperience / 5 is the
+// difficulty (capped at 90) a roll out of 100 has to beat.
+type SimpleCatchModel struct {
+	Roll roller
+}
+
+func (m SimpleCatchModel) Name() string {
+	return "simple"
+}
+
+func (m SimpleCatchModel) Attempt(target CatchTarget) CatchResult {
+	if target.BallBonus >= masterBallMultiplier {
+		return CatchResult{Caught: true}
+	}
+
+	chance := m.Roll.roll(100)
+	return CatchResult{Caught: float64(chance) > simpleDifficulty(target.Pokemon.BaseExperience, target.BallBonus)}
+}
+
+func simpleDifficulty(experienceLevel int, ballBonus float64) float64 {
+	difficulty := experienceLevel / 5
+
+	if difficulty >= 90 {
+		difficulty = 90
+	}
+
+	// Better balls shrink the difficulty the throw has to beat
+	return float64(difficulty) / ballBonus
+}
+
+// StandardCatchModel follows the Generation III/IV games: a modified catch
+// rate is built from capture_rate, HP and ball, then the ball has to pass
+// four shake checks. Status conditions aren't modelled yet, so the status
+// bonus is always 1.
+type StandardCatchModel struct {
+	Roll roller
+}
+
+func (m StandardCatchModel) Name() string {
+	return "standard"
+}
+
+func (m StandardCatchModel) Attempt(target CatchTarget) CatchResult {
+	a := modifiedCatchRate(target.CaptureRate, target.HPFraction, target.BallBonus)
+	if a >= 255 {
+		return CatchResult{Caught: true, Shakes: 4}
+	}
+
+	b := shakeThreshold(a)
+	for shakes := 0; shakes < 4; shakes++ {
+		if m.Roll.roll(65536) >= b {
+			return CatchResult{Caught: false, Shakes: shakes}
+		}
+	}
+	return CatchResult{Caught: true, Shakes: 4}
+}
+
+// modifiedCatchRate is ((3*maxHP - 2*currentHP) * rate * ball) / (3*maxHP)
+func modifiedCatchRate(captureRate int, hpFraction, ballBonus float64) float64 {
+	hpFraction = math.Max(0, math.Min(1, hpFraction))
+
+	a := (3 - 2*hpFraction) * float64(captureRate) * ballBonus / 3
+	return math.Max(1, math.Floor(a))
+}
+
+// shakeThreshold is the value each of the four 0-65535 rolls must stay under
+func shakeThreshold(a float64) int {
+	if a >= 255 {
+		return 65536
+	}
+	return int(1048560 / math.Floor(math.Sqrt(math.Floor(math.Sqrt(math.Floor(16711680/a))))))
+}
+
+func commandCatchModel(param *config) error {
+	if len(param.Args) == 0 {
+		names := []string{}
+		for name := range catchModels {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fmt.Printf("Current catch model: %v\n", param.CatchModel.Name())
+		fmt.Printf("Available: %v\n", strings.Join(names, ", "))
+		return nil
+	}
+
+	model, ok := catchModels[param.Args[0]]
+	if !ok {
+		fmt.Printf("Unknown catch model %q\n", param.Args[0])
+		return nil
+	}
+
+	param.CatchModel = model
+	fmt.Printf("Catch model set to %v\n", model.Name())
+	return nil
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/girik21/pokedexcli/internal/pokecache"
+)
+
+func TestModifiedCatchRate(t *testing.T) {
+	cases := []struct {
+		captureRate int
+		hpFraction  float64
+		ballBonus   float64
+		expected    float64
+	}{
+		{captureRate: 45, hpFraction: 1, ballBonus: 1, expected: 15},
+		{captureRate: 45, hpFraction: 0, ballBonus: 1, expected: 45},
+		{captureRate: 45, hpFraction: 0.5, ballBonus: 1, expected: 30},
+		{captureRate: 45, hpFraction: 1, ballBonus: 1.5, expected: 22},
+		{captureRate: 3, hpFraction: 1, ballBonus: 2, expected: 2},
+		{captureRate: 3, hpFraction: 1, ballBonus: 1, expected: 1},
+		{captureRate: 255, hpFraction: 1, ballBonus: 1, expected: 85},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			actual := modifiedCatchRate(c.captureRate, c.hpFraction, c.ballBonus)
+			if actual != c.expected {
+				t.Errorf("expected %v but got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestShakeThreshold(t *testing.T) {
+	cases := []struct {
+		a        float64
+		expected int
+	}{
+		{a: 1, expected: 16643},
+		{a: 15, expected: 32767},
+		{a: 85, expected: 49931},
+		{a: 255, expected: 65536},
+	}
+
+	for _, c := range cases {
+		actual := shakeThreshold(c.a)
+		if actual != c.expected {
+			t.Errorf("For a=%v, expected %v but got %v", c.a, c.expected, actual)
+		}
+	}
+}
+
+func TestStandardCatchModelAttempt(t *testing.T) {
+	fixed := func(value int) roller {
+		return func(n int) int { return value }
+	}
+
+	cases := []struct {
+		name   string
+		target CatchTarget
+		roll   roller
+		caught bool
+		shakes int
+	}{
+		{
+			name:   "master ball",
+			target: CatchTarget{CaptureRate: 3, HPFraction: 1, BallBonus: masterBallMultiplier},
+			roll:   fixed(65535),
+			caught: true,
+			shakes: 4,
+		},
+		{
+			name:   "every shake passes",
+			target: CatchTarget{CaptureRate: 45, HPFraction: 1, BallBonus: 1},
+			roll:   fixed(0),
+			caught: true,
+			shakes: 4,
+		},
+		{
+			name:   "first shake fails",
+			target: CatchTarget{CaptureRate: 45, HPFraction: 1, BallBonus: 1},
+			roll:   fixed(32767),
+			caught: false,
+			shakes: 0,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result := StandardCatchModel{Roll: c.roll}.Attempt(c.target)
+			if result.Caught != c.caught || result.Shakes != c.shakes {
+				t.Errorf("expected caught=%v shakes=%v but got %+v", c.caught, c.shakes, result)
+			}
+		})
+	}
+}
+
+func TestSimpleCatchModelAttempt(t *testing.T) {
+	cases := []struct {
+		baseExperience int
+		ballBonus      float64
+		roll           int
+		caught         bool
+	}{
+		{baseExperience: 100, ballBonus: 1, roll: 21, caught: true},
+		{baseExperience: 100, ballBonus: 1, roll: 20, caught: false},
+		{baseExperience: 100, ballBonus: 2, roll: 11, caught: true},
+		{baseExperience: 600, ballBonus: 1, roll: 90, caught: false},
+		{baseExperience: 600, ballBonus: masterBallMultiplier, roll: 0, caught: true},
+	}
+
+	for _, c := range cases {
+		roll := c.roll
+		model := SimpleCatchModel{Roll: func(n int) int { return roll }}
+
+		target := CatchTarget{BallBonus: c.ballBonus}
+		target.Pokemon.BaseExperience = c.baseExperience
+
+		if result := model.Attempt(target); result.Caught != c.caught {
+			t.Errorf("For base experience %v, ball %v and roll %v expected caught=%v", c.baseExperience, c.ballBonus, c.roll, c.caught)
+		}
+	}
+}
+
+// seedCache answers fetchData with fixtures, so commands run without the network
+func seedCache(responses map[string]string) *pokecache.Cache {
+	cache := pokecache.NewCache(time.Minute)
+	for url, body := range responses {
+		cache.Add(url, []byte(body))
+	}
+	return cache
+}
+
+// catchFixtures are the responses a catch of bulbasaur with a Poké Ball needs
+var catchFixtures = map[string]string{
+	"https://pokeapi.co/api/v2/item/poke-ball": `{"name": "poke-ball", "names": [{"name": "Poké Ball", "language": {"name": "en"}}]}`,
+	"https://pokeapi.co/api/v2/pokemon/bulbasaur": `{"id": 1, "name": "bulbasaur", "base_experience": 64,
+		"species": {"name": "bulbasaur", "url": "https://pokeapi.co/api/v2/pokemon-species/1/"},
+		"stats": [{"base_stat": 45, "stat": {"name": "hp"}}, {"base_stat": 49, "stat": {"name": "attack"}}],
+		"abilities": [
+			{"ability": {"name": "overgrow"}, "is_hidden": false, "slot": 1},
+			{"ability": {"name": "chlorophyll"}, "is_hidden": true, "slot": 3}
+		],
+		"moves": [
+			{"move": {"name": "tackle"}, "version_group_details": [
+				{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}}
+			]},
+			{"move": {"name": "growl"}, "version_group_details": [
+				{"level_learned_at": 3, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}}
+			]},
+			{"move": {"name": "vine-whip"}, "version_group_details": [
+				{"level_learned_at": 7, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}}
+			]}
+		]}`,
+	"https://pokeapi.co/api/v2/pokemon-species/1/": `{"name": "bulbasaur", "capture_rate": 45, "base_happiness": 50, "gender_rate": 1}`,
+	"https://pokeapi.co/api/v2/nature/13":          `{"name": "naughty", "increased_stat": {"name": "attack"}, "decreased_stat": {"name": "special-defense"}}`,
+}
+
+// testCatch throws a Poké Ball at bulbasaur that always catches it. Unless
+// the test picks its own, every roll for the catch lands in the middle.
+func testCatch(t *testing.T, param *config) *CaughtPokemon {
+	t.Helper()
+
+	if param.Roll == nil {
+		param.Roll = func(n int) int { return n / 2 }
+	}
+
+	param.Cache = seedCache(catchFixtures)
+	param.Inventory = map[string]int{"poke": 1}
+	param.Sandbox = true
+	param.CatchModel = StandardCatchModel{Roll: func(n int) int { return 0 }}
+	param.Args = []string{"bulbasaur"}
+
+	if err := commandCatch(param); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(param.Caught) != 1 {
+		t.Fatalf("expected bulbasaur to be caught, got %v", param.Caught)
+	}
+	return param.Caught[param.NextID]
+}
+
+func TestCatchSeedsFriendship(t *testing.T) {
+	pokemon := testCatch(t, &config{})
+	if pokemon.Friendship != 50 {
+		t.Errorf("expected friendship to start at the base happiness of 50, got %d", pokemon.Friendship)
+	}
+}
+
+func TestCatchRollsIndividual(t *testing.T) {
+	pokemon := testCatch(t, &config{ShinyOdds: 1})
+
+	if pokemon.Nature.Name != "naughty" {
+		t.Errorf("expected a naughty nature, got %v", pokemon.Nature)
+	}
+	if pokemon.IVs["hp"] != 16 || pokemon.IVs["speed"] != 16 {
+		t.Errorf("expected every IV rolled to 16, got %v", pokemon.IVs)
+	}
+	if pokemon.Ability != "overgrow" || pokemon.HiddenAbility {
+		t.Errorf("expected the regular ability overgrow, got %v (hidden %v)", pokemon.Ability, pokemon.HiddenAbility)
+	}
+	if pokemon.Gender != "male" || !pokemon.Shiny {
+		t.Errorf("expected a shiny male, got %v (shiny %v)", pokemon.Gender, pokemon.Shiny)
+	}
+	if pokemon.Stats["attack"] == computeStats(pokemon.Data, pokemon.Level, nil, nil, Nature{})["attack"] {
+		t.Errorf("expected stats recalculated with the rolled IVs and nature, got %v", pokemon.Stats)
+	}
+}
+
+func TestCatchKnowsStartingMoves(t *testing.T) {
+	pokemon := testCatch(t, &config{})
+	if !reflect.DeepEqual(pokemon.Moves, []string{"growl", "tackle"}) {
+		t.Errorf("expected the level 5 catch to know growl and tackle, got %v", pokemon.Moves)
+	}
+}
+
+func TestCatchChecksNickname(t *testing.T) {
+	param := &config{
+		Caught: map[int]*CaughtPokemon{1: {ID: 1, Species: "pikachu", Nickname: "sparky"}},
+		NextID: 1,
+		Roll:   func(n int) int { return n / 2 },
+	}
+
+	cases := []struct {
+		nickname string
+		caught   int
+	}{
+		{nickname: "sparky", caught: 1},
+		{nickname: "#7", caught: 1},
+		{nickname: "bulby", caught: 2},
+	}
+
+	for _, c := range cases {
+		param.Cache = seedCache(catchFixtures)
+		param.Inventory = map[string]int{"poke": 1}
+		param.Sandbox = true
+		param.CatchModel = StandardCatchModel{Roll: func(n int) int { return 0 }}
+		param.Args = []string{"bulbasaur", "--nickname", c.nickname}
+
+		if err := commandCatch(param); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if len(param.Caught) != c.caught {
+			t.Errorf("For --nickname %v, expected %d caught but got %d", c.nickname, c.caught, len(param.Caught))
+		}
+	}
+	if param.Caught[2].Nickname != "bulby" {
+		t.Errorf("expected the catch to be called bulby, got %v", param.Caught[2].Nickname)
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// No base stat goes above this, so bars are drawn as a fraction of it
+const maxBaseStat = 255
+
+// Columns of the compare table are this far apart
+const compareGutter = 2
+
+// statBar draws value out of maxBaseStat as width characters of blocks
+func statBar(value, width int) string {
+	filled := min(width, (value*width+maxBaseStat-1)/maxBaseStat)
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// bestIndexes lists the columns holding the highest value, or none when
+// every column is the same
+func bestIndexes(values []int) []int {
+	best, differ := values[0], false
+	for _, v := range values[1:] {
+		if v != values[0] {
+			differ = true
+		}
+		best = max(best, v)
+	}
+	if !differ {
+		return nil
+	}
+
+	indexes := []int{}
+	for i, v := range values {
+		if v == best {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// matchup is the best multiplier any of the attacker's types gets against the defender
+func matchup(chart *TypeChart, attackerTypes, defenderTypes []string) float64 {
+	best := 0.0
+	for _, t := range attackerTypes {
+		best = max(best, chart.effectiveness(t, defenderTypes))
+	}
+	return best
+}
+
+// padRight pads to width runes, so block characters line up
+func padRight(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
+
+// compareRow is one line of the compare table, best lists the highlighted columns
+type compareRow struct {
+	label string
+	cells []string
+	best  []int
+}
+
+// statRow shows each value, with a bar when barWidth is set. The best values
+// are highlighted and the rest show how far behind the best they are.
+func statRow(label string, values []int, barWidth int) compareRow {
+	row := compareRow{label: label, best: bestIndexes(values)}
+	for i, v := range values {
+		cell := fmt.Sprintf("%3d", v)
+		if barWidth > 0 {
+			cell += " " + statBar(v, barWidth)
+		}
+		if len(row.best) > 0 && indexOf(row.best, i) < 0 {
+			cell += fmt.Sprintf(" (%d)", v-values[row.best[0]])
+		}
+		row.cells = append(row.cells, cell)
+	}
+	return row
+}
+
+// formatCompareTable sizes each column to its widest cell plus a gutter. The
+// best cells are bold green, or get a * where there's no color.
+func formatCompareTable(rows []compareRow, color bool) string {
+	text := func(row compareRow, i int) string {
+		if !color && indexOf(row.best, i) >= 0 {
+			return row.cells[i] + "*"
+		}
+		return row.cells[i]
+	}
+
+	labelWidth := 0
+	widths := []int{}
+	for _, row := range rows {
+		labelWidth = max(labelWidth, utf8.RuneCountInString(row.label))
+		for i := range row.cells {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], utf8.RuneCountInString(text(row, i)))
+		}
+	}
+
+	var b strings.Builder
+	for _, row := range rows {
+		var line strings.Builder
+		line.WriteString(padRight(row.label, labelWidth+compareGutter))
+		for i := range row.cells {
+			cell := text(row, i)
+			padding := strings.Repeat(" ", widths[i]+compareGutter-utf8.RuneCountInString(cell))
+			if color && indexOf(row.best, i) >= 0 {
+				cell = "\x1b[1;32m" + cell + "\x1b[0m"
+			}
+			line.WriteString(cell + padding)
+		}
+		b.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+	}
+	return b.String()
+}
+
+// abilityNames lists a species' abilities, adding hiddenMark to the hidden one
+func abilityNames(pokemonData PokeData, hiddenMark string) string {
+	names := []string{}
+	for _, a := range pokemonData.Abilities {
+		name := a.Ability.Name
+		if a.IsHidden {
+			name += hiddenMark
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func commandCompare(param *config) error {
+	if len(param.Args) < 2 {
+		fmt.Println("Usage: compare <pokemon> <pokemon> [more pokemon...]")
+		return nil
+	}
+
+	pokemon := []PokeData{}
+	for _, ref := range param.Args {
+		pokemonData, err := lookupPokemon(param, ref)
+		if err != nil {
+			fmt.Printf("Error finding %v, Make sure it exists\n", ref)
+			return err
+		}
+		pokemon = append(pokemon, pokemonData)
+	}
+
+	chart, err := loadTypeChart(param)
+	if err != nil {
+		fmt.Println("Error loading the type chart")
+		return err
+	}
+
+	saved := []SavedPokemon{}
+	names := []string{}
+	for _, p := range pokemon {
+		saved = append(saved, savePokemon(p))
+		names = append(names, localName(param, "pokemon-species", p.Species.Name))
+	}
+
+	types := []string{}
+	heights := []string{}
+	weights := []string{}
+	abilities := []string{}
+	for i, s := range saved {
+		types = append(types, strings.Join(s.Types, "/"))
+		heights = append(heights, formatHeight(s.Height))
+		weights = append(weights, formatWeight(s.Weight))
+		abilities = append(abilities, abilityNames(pokemon[i], " (H)"))
+	}
+	rows := []compareRow{
+		{label: "", cells: names},
+		{label: "Types", cells: types},
+		{label: "Height", cells: heights},
+		{label: "Weight", cells: weights},
+		{label: "Abilities", cells: abilities},
+	}
+
+	totals := make([]int, len(saved))
+	for _, stat := range statOrder {
+		values := []int{}
+		for i, s := range saved {
+			values = append(values, s.Stats[stat])
+			totals[i] += s.Stats[stat]
+		}
+		rows = append(rows, statRow(stat, values, 15))
+	}
+	rows = append(rows, statRow("Total", totals, 0))
+	fmt.Print(formatCompareTable(rows, supportsColor()))
+
+	fmt.Println("Matchups (best multiplier from the attacker's types):")
+	for i, attacker := range saved {
+		for j, defender := range saved {
+			if i == j {
+				continue
+			}
+			fmt.Printf("  %v vs %v: %gx\n", names[i], names[j], matchup(chart, attacker.Types, defender.Types))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStatBar(t *testing.T) {
+	cases := []struct {
+		value    int
+		expected string
+	}{
+		{value: 0, expected: "░░░░░"},
+		{value: 1, expected: "█░░░░"},
+		{value: 100, expected: "██░░░"},
+		{value: 255, expected: "█████"},
+	}
+
+	for _, c := range cases {
+		if actual := statBar(c.value, 5); actual != c.expected {
+			t.Errorf("For %d, expected %q but got %q", c.value, c.expected, actual)
+		}
+	}
+}
+
+func TestBestIndexes(t *testing.T) {
+	cases := []struct {
+		values   []int
+		expected []int
+	}{
+		{values: []int{45, 80, 60}, expected: []int{1}},
+		{values: []int{100, 80, 100}, expected: []int{0, 2}},
+		{values: []int{70, 70}, expected: nil},
+	}
+
+	for _, c := range cases {
+		if actual := bestIndexes(c.values); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("For %v, expected %v but got %v", c.values, c.expected, actual)
+		}
+	}
+}
+
+func TestMatchup(t *testing.T) {
+	chart := testTypeChart()
+
+	cases := []struct {
+		attacker []string
+		defender []string
+		expected float64
+	}{
+		{attacker: []string{"water"}, defender: []string{"ground"}, expected: 2},
+		{attacker: []string{"electric"}, defender: []string{"ground"}, expected: 0},
+		{attacker: []string{"electric", "grass"}, defender: []string{"ground"}, expected: 2},
+		{attacker: []string{"grass"}, defender: []string{"water", "ground"}, expected: 4},
+	}
+
+	for _, c := range cases {
+		if actual := matchup(chart, c.attacker, c.defender); actual != c.expected {
+			t.Errorf("For %v against %v, expected %v but got %v", c.attacker, c.defender, c.expected, actual)
+		}
+	}
+}
+
+func TestStatRow(t *testing.T) {
+	row := statRow("hp", []int{45, 80, 60}, 0)
+	expected := []string{" 45 (-35)", " 80", " 60 (-20)"}
+	if !reflect.DeepEqual(row.cells, expected) || !reflect.DeepEqual(row.best, []int{1}) {
+		t.Errorf("expected %q with #1 best but got %q with %v", expected, row.cells, row.best)
+	}
+
+	if even := statRow("hp", []int{70, 70}, 0); !reflect.DeepEqual(even.cells, []string{" 70", " 70"}) {
+		t.Errorf("expected no differences between equal values, got %q", even.cells)
+	}
+}
+
+func TestFormatCompareTable(t *testing.T) {
+	rows := []compareRow{
+		{label: "", cells: []string{"bulbasaur", "pikachu"}},
+		{label: "Abilities", cells: []string{"overgrow, chlorophyll (H)", "static, lightning-rod (H)"}},
+		statRow("speed", []int{45, 90}, 0),
+	}
+
+	expected := "           bulbasaur                  pikachu\n" +
+		"Abilities  overgrow, chlorophyll (H)  static, lightning-rod (H)\n" +
+		"speed       45 (-45)                   90*\n"
+	if actual := formatCompareTable(rows, false); actual != expected {
+		t.Errorf("expected\n%v\nbut got\n%v", expected, actual)
+	}
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WildPokemon is the pokemon the trainer is currently facing
+type WildPokemon struct {
+	Name    string
+	Level   int
+	Method  string
+	Version string
+	HP      int // Only known once it has been in a battle
+	MaxHP   int
+}
+
+// hpFraction is how much health the wild pokemon has left, 1 when untouched
+func (w *WildPokemon) hpFraction() float64 {
+	if w.MaxHP == 0 {
+		return 1
+	}
+	return float64(w.HP) / float64(w.MaxHP)
+}
+
+// inBattle stops commands that would walk away from an ongoing battle
+func inBattle(param *config) bool {
+	if param.Battle != nil {
+		fmt.Println("You're in a battle! Use fight or run first")
+		return true
+	}
+	return false
+}
+
+type encounterSlot struct {
+	Pokemon  string
+	ID       int // National dex number, or a form's ID past it
+	Chance   int
+	MinLevel int
+	MaxLevel int
+	Method   string
+}
+
+// encounterSlots flattens an area's encounter table for one version,
+// keeping only the methods accepted by matchMethod.
+func encounterSlots(area PokeLocation, version string, matchMethod func(string) bool) []encounterSlot {
+	slots := []encounterSlot{}
+
+	for _, encounter := range area.PokemonEncounters {
+		for _, versionDetail := range encounter.VersionDetails {
+			if versionDetail.Version.Name != version {
+				continue
+			}
+
+			for _, detail := range versionDetail.EncounterDetails {
+				if !matchMethod(detail.Method.Name) {
+					continue
+				}
+
+				slots = append(slots, encounterSlot{
+					Pokemon:  encounter.Pokemon.Name,
+					ID:       idFromURL(encounter.Pokemon.URL),
+					Chance:   detail.Chance,
+					MinLevel: detail.MinLevel,
+					MaxLevel: detail.MaxLevel,
+					Method:   detail.Method.Name,
+				})
+			}
+		}
+	}
+	return slots
+}
+
+// areaVersions lists every game version with encounter data in an area
+func areaVersions(area PokeLocation) []string {
+	versions := []string{}
+	for _, encounter := range area.PokemonEncounters {
+		for _, versionDetail := range encounter.VersionDetails {
+			if !containsString(versions, versionDetail.Version.Name) {
+				versions = append(versions, versionDetail.Version.Name)
+			}
+		}
+	}
+	return versions
+}
+
+// pickEncounter chooses a slot weighted by its Chance and rolls a level in range
+func pickEncounter(slots []encounterSlot, r roller) (encounterSlot, int) {
+	total := 0
+	for _, slot := range slots {
+		total += slot.Chance
+	}
+
+	picked := slots[len(slots)-1]
+	if total > 0 {
+		target := r.roll(total)
+		for _, slot := range slots {
+			if target < slot.Chance {
+				picked = slot
+				break
+			}
+			target -= slot.Chance
+		}
+	}
+
+	level := picked.MinLevel
+	if picked.MaxLevel > picked.MinLevel {
+		level += r.roll(picked.MaxLevel - picked.MinLevel + 1)
+	}
+	return picked, level
+}
+
+func startEncounter(param *config, description string, matchMethod func(string) bool) error {
+	if inBattle(param) {
+		return nil
+	}
+	if param.CurrentArea == nil {
+		fmt.Println("You aren't anywhere yet! Use explore or travel to pick an area first")
+		return nil
+	}
+
+	_, flags := parseArgs(param.Args)
+
+	versions := areaVersions(*param.CurrentArea)
+	if len(versions) == 0 {
+		fmt.Printf("No wild pokemon live in %v\n", param.CurrentArea.Name)
+		return nil
+	}
+
+	version := versions[0]
+	if param.Version != "" {
+		version = param.Version
+	}
+	if value, ok := flags["version"]; ok {
+		version = value
+	}
+
+	slots := encounterSlots(*param.CurrentArea, version, matchMethod)
+	if len(slots) == 0 {
+		fmt.Printf("Nothing turns up %v in %v (%v)\n", description, param.CurrentArea.Name, version)
+		return nil
+	}
+
+	slot, level := pickEncounter(slots, param.Roll)
+	markSeen(param, slot.ID)
+	param.Wild = &WildPokemon{
+		Name:    slot.Pokemon,
+		Level:   level,
+		Method:  slot.Method,
+		Version: version,
+	}
+
+	fmt.Printf("A wild level %d %v appeared! (%v, %v)\n", level, slot.Pokemon, slot.Method, version)
+	return nil
+}
+
+func commandEncounter(param *config) error {
+	positional, _ := parseArgs(param.Args)
+	if len(positional) == 0 {
+		return startEncounter(param, "around", func(string) bool { return true })
+	}
+
+	method := positional[0]
+	return startEncounter(param, "using "+method, func(m string) bool { return m == method })
+}
+
+func commandWalk(param *config) error {
+	return startEncounter(param, "in the grass", func(m string) bool { return m == "walk" })
+}
+
+func commandSurf(param *config) error {
+	return startEncounter(param, "on the water", func(m string) bool { return m == "surf" })
+}
+
+// commandFish casts any rod by default, or only old/good/super when given
+func commandFish(param *config) error {
+	positional, _ := parseArgs(param.Args)
+	if len(positional) > 0 {
+		rod := strings.TrimSuffix(positional[0], "-rod") + "-rod"
+		return startEncounter(param, "with the "+rod, func(m string) bool { return m == rod })
+	}
+	return startEncounter(param, "on the line", func(m string) bool { return strings.HasSuffix(m, "-rod") })
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const encounterFixture = `{
+	"name": "test-area",
+	"pokemon_encounters": [
+		{
+			"pokemon": {"name": "pidgey"},
+			"version_details": [
+				{"version": {"name": "red"}, "encounter_details": [
+					{"chance": 30, "min_level": 2, "max_level": 5, "method": {"name": "walk"}}
+				]},
+				{"version": {"name": "blue"}, "encounter_details": [
+					{"chance": 50, "min_level": 3, "max_level": 3, "method": {"name": "walk"}}
+				]}
+			]
+		},
+		{
+			"pokemon": {"name": "magikarp"},
+			"version_details": [
+				{"version": {"name": "red"}, "encounter_details": [
+					{"chance": 100, "min_level": 5, "max_level": 5, "method": {"name": "old-rod"}}
+				]}
+			]
+		},
+		{
+			"pokemon": {"name": "rattata"},
+			"version_details": [
+				{"version": {"name": "red"}, "encounter_details": [
+					{"chance": 70, "min_level": 2, "max_level": 4, "method": {"name": "walk"}}
+				]}
+			]
+		}
+	]
+}`
+
+func TestEncounterSlots(t *testing.T) {
+	var area PokeLocation
+	if err := json.Unmarshal([]byte(encounterFixture), &area); err != nil {
+		t.Fatalf("could not decode fixture: %v", err)
+	}
+
+	walking := func(m string) bool { return m == "walk" }
+
+	cases := []struct {
+		version  string
+		expected []string
+	}{
+		{version: "red", expected: []string{"pidgey", "rattata"}},
+		{version: "blue", expected: []string{"pidgey"}},
+		{version: "gold", expected: []string{}},
+	}
+
+	for _, c := range cases {
+		slots := encounterSlots(area, c.version, walking)
+		if len(slots) != len(c.expected) {
+			t.Errorf("For version %v, expected %v slots but got %v", c.version, len(c.expected), len(slots))
+			continue
+		}
+		for i := range slots {
+			if slots[i].Pokemon != c.expected[i] {
+				t.Errorf("For version %v, expected %v but got %v", c.version, c.expected[i], slots[i].Pokemon)
+			}
+		}
+	}
+}
+
+func TestPickEncounter(t *testing.T) {
+	slots := []encounterSlot{
+		{Pokemon: "pidgey", Chance: 30, MinLevel: 2, MaxLevel: 5},
+		{Pokemon: "rattata", Chance: 70, MinLevel: 2, MaxLevel: 4},
+	}
+
+	cases := []struct {
+		rolls    []int
+		expected string
+		level    int
+	}{
+		{rolls: []int{0, 0}, expected: "pidgey", level: 2},
+		{rolls: []int{29, 3}, expected: "pidgey", level: 5},
+		{rolls: []int{30, 1}, expected: "rattata", level: 3},
+		{rolls: []int{99, 2}, expected: "rattata", level: 4},
+	}
+
+	for _, c := range cases {
+		rolls := c.rolls
+		next := func(n int) int {
+			value := rolls[0]
+			rolls = rolls[1:]
+			return value
+		}
+
+		slot, level := pickEncounter(slots, next)
+		if slot.Pokemon != c.expected || level != c.level {
+			t.Errorf("For rolls %v, expected level %v %v but got level %v %v", c.rolls, c.level, c.expected, level, slot.Pokemon)
+		}
+	}
+}
+
+func TestWalkUsesConfigRoll(t *testing.T) {
+	var area PokeLocation
+	if err := json.Unmarshal([]byte(encounterFixture), &area); err != nil {
+		t.Fatalf("could not decode fixture: %v", err)
+	}
+
+	rolls := []int{99, 2}
+	param := &config{
+		CurrentArea: &area,
+		Version:     "red",
+		Roll: func(n int) int {
+			value := rolls[0]
+			rolls = rolls[1:]
+			return value
+		},
+	}
+
+	if err := commandWalk(param); err != nil {
+		t.Fatalf("walk failed: %v", err)
+	}
+	if param.Wild == nil || param.Wild.Name != "rattata" || param.Wild.Level != 4 {
+		t.Errorf("expected a level 4 rattata but got %+v", param.Wild)
+	}
+}
@@ -0,0 +1,355 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type EvolutionDetail struct {
+	Trigger       NamedAPIResource  `json:"trigger"`
+	MinLevel      int               `json:"min_level"`
+	MinHappiness  int               `json:"min_happiness"`
+	MinAffection  int               `json:"min_affection"`
+	MinBeauty     int               `json:"min_beauty"`
+	TimeOfDay     string            `json:"time_of_day"`
+	Gender        *int              `json:"gender"`
+	Item          *NamedAPIResource `json:"item"`
+	HeldItem      *NamedAPIResource `json:"held_item"`
+	KnownMove     *NamedAPIResource `json:"known_move"`
+	KnownMoveType *NamedAPIResource `json:"known_move_type"`
+	Location      *NamedAPIResource `json:"location"`
+	TradeSpecies  *NamedAPIResource `json:"trade_species"`
+	NeedsRain     bool              `json:"needs_overworld_rain"`
+	UpsideDown    bool              `json:"turn_upside_down"`
+}
+
+type ChainLink struct {
+	Species          NamedAPIResource  `json:"species"`
+	EvolutionDetails []EvolutionDetail `json:"evolution_details"`
+	EvolvesTo        []ChainLink       `json:"evolves_to"`
+}
+
+type PokeEvolutionChain struct {
+	ID    int       `json:"id"`
+	Chain ChainLink `json:"chain"`
+}
+
+func fetchEvolutionChain(param *config, pokemonData PokeData) (PokeEvolutionChain, error) {
+	var chain PokeEvolutionChain
+
+	species, err := fetchSpecies(param, pokemonData)
+	if err != nil {
+		return chain, err
+	}
+
+	body, err := fetchData(param, species.EvolutionChain.URL)
+	if err != nil {
+		return chain, err
+	}
+
+	if err := json.Unmarshal(body, &chain); err != nil {
+		return chain, err
+	}
+	return chain, nil
+}
+
+// describeEvolution turns one set of evolution conditions into a short
+// phrase like "level 16" or "use water-stone, day"
+func describeEvolution(detail EvolutionDetail) string {
+	parts := []string{}
+
+	switch detail.Trigger.Name {
+	case "level-up":
+		if detail.MinLevel > 0 {
+			parts = append(parts, fmt.Sprintf("level %d", detail.MinLevel))
+		} else {
+			parts = append(parts, "level up")
+		}
+	case "use-item":
+		if detail.Item != nil {
+			parts = append(parts, "use "+detail.Item.Name)
+		}
+	case "trade":
+		parts = append(parts, "trade")
+	default:
+		parts = append(parts, detail.Trigger.Name)
+	}
+
+	if detail.MinHappiness > 0 {
+		parts = append(parts, fmt.Sprintf("friendship %d", detail.MinHappiness))
+	}
+	if detail.MinAffection > 0 {
+		parts = append(parts, fmt.Sprintf("affection %d", detail.MinAffection))
+	}
+	if detail.MinBeauty > 0 {
+		parts = append(parts, fmt.Sprintf("beauty %d", detail.MinBeauty))
+	}
+	if detail.TimeOfDay != "" {
+		parts = append(parts, detail.TimeOfDay)
+	}
+	if detail.HeldItem != nil {
+		parts = append(parts, "holding "+detail.HeldItem.Name)
+	}
+	if detail.KnownMove != nil {
+		parts = append(parts, "knowing "+detail.KnownMove.Name)
+	}
+	if detail.KnownMoveType != nil {
+		parts = append(parts, "knowing a "+detail.KnownMoveType.Name+" move")
+	}
+	if detail.Location != nil {
+		parts = append(parts, "at "+detail.Location.Name)
+	}
+	if detail.TradeSpecies != nil {
+		parts = append(parts, "for "+detail.TradeSpecies.Name)
+	}
+	if detail.Gender != nil {
+		parts = append(parts, map[int]string{1: "female", 2: "male"}[*detail.Gender])
+	}
+	if detail.NeedsRain {
+		parts = append(parts, "in the rain")
+	}
+	if detail.UpsideDown {
+		parts = append(parts, "upside down")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// renderChain draws the chain as a tree, one species per line
+func renderChain(link ChainLink) []string {
+	lines := []string{link.Species.Name}
+	lines = append(lines, renderBranches(link, "")...)
+	return lines
+}
+
+func renderBranches(link ChainLink, prefix string) []string {
+	lines := []string{}
+
+	for i, next := range link.EvolvesTo {
+		branch, indent := "├─ ", "│  "
+		if i == len(link.EvolvesTo)-1 {
+			branch, indent = "└─ ", "   "
+		}
+
+		triggers := []string{}
+		for _, detail := range next.EvolutionDetails {
+			triggers = append(triggers, describeEvolution(detail))
+		}
+
+		lines = append(lines, fmt.Sprintf("%v%v%v (%v)", prefix, branch, next.Species.Name, strings.Join(triggers, " or ")))
+		lines = append(lines, renderBranches(next, prefix+indent)...)
+	}
+	return lines
+}
+
+// findLink finds the chain entry of a species
+func findLink(link ChainLink, species string) *ChainLink {
+	if link.Species.Name == species {
+		return &link
+	}
+	for _, next := range link.EvolvesTo {
+		if found := findLink(next, species); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// timeOfDay splits the clock the way the games do for evolution
+func timeOfDay(now time.Time) string {
+	if hour := now.Hour(); hour >= 6 && hour < 18 {
+		return "day"
+	}
+	return "night"
+}
+
+// evolveAttempt is what the trainer is doing when trying to evolve a pokemon
+type evolveAttempt struct {
+	Item  string
+	Trade bool
+	Now   time.Time
+	Area  string // Location the trainer is in
+}
+
+// unmetConditions lists why a pokemon can't evolve with detail right now,
+// an empty list means it can.
+func unmetConditions(pokemon *CaughtPokemon, detail EvolutionDetail, attempt evolveAttempt) []string {
+	unmet := []string{}
+
+	switch detail.Trigger.Name {
+	case "level-up":
+		if pokemon.Level < detail.MinLevel {
+			unmet = append(unmet, fmt.Sprintf("needs level %d", detail.MinLevel))
+		}
+	case "use-item":
+		if detail.Item == nil || attempt.Item != detail.Item.Name {
+			unmet = append(unmet, "needs "+describeEvolution(detail))
+		}
+	case "trade":
+		if !attempt.Trade {
+			unmet = append(unmet, "needs to be traded (--trade)")
+		}
+	default:
+		unmet = append(unmet, "needs "+detail.Trigger.Name+", which isn't supported")
+	}
+
+	if pokemon.Friendship < detail.MinHappiness {
+		unmet = append(unmet, fmt.Sprintf("needs friendship %d (has %d)", detail.MinHappiness, pokemon.Friendship))
+	}
+	if detail.TimeOfDay != "" && detail.TimeOfDay != timeOfDay(attempt.Now) {
+		unmet = append(unmet, "only evolves at "+detail.TimeOfDay)
+	}
+	if detail.HeldItem != nil && pokemon.HeldItem != detail.HeldItem.Name {
+		unmet = append(unmet, "needs to hold "+detail.HeldItem.Name)
+	}
+	if detail.KnownMove != nil && !containsString(pokemon.Moves, detail.KnownMove.Name) {
+		unmet = append(unmet, "needs to know "+detail.KnownMove.Name)
+	}
+	if detail.KnownMoveType != nil {
+		unmet = append(unmet, "needs to know a "+detail.KnownMoveType.Name+" move")
+	}
+	if detail.Location != nil && detail.Location.Name != attempt.Area {
+		unmet = append(unmet, "needs to be at "+detail.Location.Name)
+	}
+	if detail.MinAffection > 0 || detail.MinBeauty > 0 || detail.NeedsRain || detail.UpsideDown || detail.TradeSpecies != nil || detail.Gender != nil {
+		unmet = append(unmet, "has conditions that aren't supported")
+	}
+	return unmet
+}
+
+// friendshipGain is how much friendship a pokemon gets for leveling up
+func friendshipGain(friendship int) int {
+	switch {
+	case friendship < 100:
+		return 5
+	case friendship < 200:
+		return 3
+	}
+	return 2
+}
+
+func commandEvolution(param *config) error {
+	if len(param.Args) == 0 {
+		fmt.Println("Which pokemon's evolutions should be shown?")
+		return nil
+	}
+
+	pokemonData, err := lookupPokemon(param, param.Args[0])
+	if err != nil {
+		fmt.Println("Error finding the pokemon, Make sure it exists")
+		return err
+	}
+
+	chain, err := fetchEvolutionChain(param, pokemonData)
+	if err != nil {
+		fmt.Println("Error loading the evolution chain")
+		return err
+	}
+
+	for _, line := range renderChain(chain.Chain) {
+		fmt.Println(line)
+	}
+	return nil
+}
+
+func commandEvolve(param *config) error {
+	positional, flags := parseArgs(param.Args, "trade")
+	if len(positional) == 0 {
+		fmt.Println("Usage: evolve <pokemon> [--item <item>] [--trade]")
+		return nil
+	}
+
+	pokemon, err := findCaught(param, positional[0])
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+
+	item := flags["item"]
+	if item != "" && param.Bag[item] <= 0 {
+		fmt.Printf("You don't have any %v in your bag\n", item)
+		return nil
+	}
+
+	_, err = tryEvolve(param, pokemon, evolveAttempt{
+		Item:  item,
+		Trade: flags["trade"] == "true",
+	})
+	return err
+}
+
+// tryEvolve evolves a pokemon along the first branch of its chain that the
+// attempt satisfies, using up the evolution item or held item it needed.
+// When no branch fits it prints what is missing and returns false.
+func tryEvolve(param *config, pokemon *CaughtPokemon, attempt evolveAttempt) (bool, error) {
+	if err := pokemon.loadData(param); err != nil {
+		fmt.Println("Error loading the pokemon from pokeAPI")
+		return false, err
+	}
+	chain, err := fetchEvolutionChain(param, pokemon.Data)
+	if err != nil {
+		fmt.Println("Error loading the evolution chain")
+		return false, err
+	}
+
+	link := findLink(chain.Chain, pokemon.Data.Species.Name)
+	if link == nil || len(link.EvolvesTo) == 0 {
+		fmt.Printf("%v doesn't evolve any further\n", pokemon.displayName())
+		return false, nil
+	}
+
+	attempt.Now = time.Now()
+	if param.CurrentArea != nil {
+		attempt.Area = param.CurrentArea.Location.Name
+	}
+
+	reasons := []string{}
+	for _, next := range link.EvolvesTo {
+		for _, detail := range next.EvolutionDetails {
+			unmet := unmetConditions(pokemon, detail, attempt)
+			if len(unmet) > 0 {
+				reasons = append(reasons, fmt.Sprintf("%v: %v", next.Species.Name, strings.Join(unmet, ", ")))
+				continue
+			}
+
+			if err := evolveInto(param, pokemon, next.Species.Name); err != nil {
+				return false, err
+			}
+			if detail.Trigger.Name == "use-item" {
+				removeFromBag(param, attempt.Item)
+			}
+			if detail.HeldItem != nil {
+				pokemon.HeldItem = ""
+			}
+			return true, nil
+		}
+	}
+
+	fmt.Printf("%v can't evolve yet\n", pokemon.displayName())
+	for _, reason := range reasons {
+		fmt.Printf(" - %v\n", reason)
+	}
+	return false, nil
+}
+
+func evolveInto(param *config, pokemon *CaughtPokemon, species string) error {
+	pokemonData, err := fetchDefaultVariety(param, species)
+	if err != nil {
+		fmt.Println("Error loading the evolved pokemon")
+		return err
+	}
+
+	before := pokemon.displayName()
+	pokemon.Species = pokemonData.Name
+	pokemon.Data = pokemonData
+	if ability := abilityForSlot(pokemonData, pokemon.AbilitySlot); ability != "" {
+		pokemon.Ability = ability
+	}
+	pokemon.recalcStats()
+	registerCaught(param, pokemon)
+
+	fmt.Printf("What? %v is evolving!\n", before)
+	fmt.Printf("Congratulations! It evolved into %v!\n", pokemonData.Name)
+	return nil
+}
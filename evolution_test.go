@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+const eeveeFixture = `{
+	"species": {"name": "eevee"},
+	"evolves_to": [
+		{"species": {"name": "vaporeon"}, "evolution_details": [
+			{"trigger": {"name": "use-item"}, "item": {"name": "water-stone"}}
+		], "evolves_to": []},
+		{"species": {"name": "espeon"}, "evolution_details": [
+			{"trigger": {"name": "level-up"}, "min_happiness": 160, "time_of_day": "day"}
+		], "evolves_to": []}
+	]
+}`
+
+const bulbasaurFixture = `{
+	"species": {"name": "bulbasaur"},
+	"evolves_to": [
+		{"species": {"name": "ivysaur"}, "evolution_details": [
+			{"trigger": {"name": "level-up"}, "min_level": 16}
+		], "evolves_to": [
+			{"species": {"name": "venusaur"}, "evolution_details": [
+				{"trigger": {"name": "level-up"}, "min_level": 32}
+			], "evolves_to": []}
+		]}
+	]
+}`
+
+func decodeChain(t *testing.T, fixture string) ChainLink {
+	var link ChainLink
+	if err := json.Unmarshal([]byte(fixture), &link); err != nil {
+		t.Fatalf("could not decode fixture: %v", err)
+	}
+	return link
+}
+
+func TestRenderChain(t *testing.T) {
+	cases := []struct {
+		fixture  string
+		expected []string
+	}{
+		{
+			fixture: bulbasaurFixture,
+			expected: []string{
+				"bulbasaur",
+				"└─ ivysaur (level 16)",
+				"   └─ venusaur (level 32)",
+			},
+		},
+		{
+			fixture: eeveeFixture,
+			expected: []string{
+				"eevee",
+				"├─ vaporeon (use water-stone)",
+				"└─ espeon (level up, friendship 160, day)",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		actual := renderChain(decodeChain(t, c.fixture))
+		if strings.Join(actual, "\n") != strings.Join(c.expected, "\n") {
+			t.Errorf("expected\n%v\nbut got\n%v", strings.Join(c.expected, "\n"), strings.Join(actual, "\n"))
+		}
+	}
+}
+
+func TestUnmetConditions(t *testing.T) {
+	eevee := decodeChain(t, eeveeFixture)
+	bulbasaur := decodeChain(t, bulbasaurFixture)
+
+	noon := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	midnight := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name      string
+		pokemon   CaughtPokemon
+		detail    EvolutionDetail
+		attempt   evolveAttempt
+		canEvolve bool
+	}{
+		{name: "level reached", pokemon: CaughtPokemon{Level: 16}, detail: bulbasaur.EvolvesTo[0].EvolutionDetails[0], canEvolve: true},
+		{name: "level too low", pokemon: CaughtPokemon{Level: 15}, detail: bulbasaur.EvolvesTo[0].EvolutionDetails[0], canEvolve: false},
+		{name: "stone used", detail: eevee.EvolvesTo[0].EvolutionDetails[0], attempt: evolveAttempt{Item: "water-stone"}, canEvolve: true},
+		{name: "wrong stone", detail: eevee.EvolvesTo[0].EvolutionDetails[0], attempt: evolveAttempt{Item: "fire-stone"}, canEvolve: false},
+		{name: "friendly at day", pokemon: CaughtPokemon{Friendship: 200}, detail: eevee.EvolvesTo[1].EvolutionDetails[0], attempt: evolveAttempt{Now: noon}, canEvolve: true},
+		{name: "friendly at night", pokemon: CaughtPokemon{Friendship: 200}, detail: eevee.EvolvesTo[1].EvolutionDetails[0], attempt: evolveAttempt{Now: midnight}, canEvolve: false},
+		{name: "not friendly", pokemon: CaughtPokemon{Friendship: 70}, detail: eevee.EvolvesTo[1].EvolutionDetails[0], attempt: evolveAttempt{Now: noon}, canEvolve: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			unmet := unmetConditions(&c.pokemon, c.detail, c.attempt)
+			if (len(unmet) == 0) != c.canEvolve {
+				t.Errorf("expected canEvolve=%v but got unmet conditions %v", c.canEvolve, unmet)
+			}
+		})
+	}
+}
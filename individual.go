@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+const defaultShinyOdds = 4096
+const hiddenAbilityOdds = 20
+const natureCount = 25
+
+// Nature raises one stat by 10% and lowers another by 10%, or neither
+type Nature struct {
+	Name      string `json:"name"`
+	Increased string `json:"increased,omitempty"`
+	Decreased string `json:"decreased,omitempty"`
+}
+
+type PokeNature struct {
+	ID            int               `json:"id"`
+	Name          string            `json:"name"`
+	IncreasedStat *NamedAPIResource `json:"increased_stat"`
+	DecreasedStat *NamedAPIResource `json:"decreased_stat"`
+}
+
+func fetchNature(param *config, id int) (Nature, error) {
+	var raw PokeNature
+
+	body, err := fetchData(param, fmt.Sprintf("https://pokeapi.co/api/v2/nature/%d", id))
+	if err != nil {
+		return Nature{}, err
+	}
+
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return Nature{}, err
+	}
+
+	nature := Nature{Name: raw.Name}
+	if raw.IncreasedStat != nil && raw.DecreasedStat != nil && raw.IncreasedStat.Name != raw.DecreasedStat.Name {
+		nature.Increased = raw.IncreasedStat.Name
+		nature.Decreased = raw.DecreasedStat.Name
+	}
+	return nature, nil
+}
+
+func (n Nature) modifier(stat string) float64 {
+	switch {
+	case n.Increased == "":
+		return 1
+	case stat == n.Increased:
+		return 1.1
+	case stat == n.Decreased:
+		return 0.9
+	}
+	return 1
+}
+
+func (n Nature) String() string {
+	if n.Increased == "" {
+		return n.Name
+	}
+	return fmt.Sprintf("%v (+%v, -%v)", n.Name, n.Increased, n.Decreased)
+}
+
+func rollIVs(r roller) map[string]int {
+	ivs := make(map[string]int)
+	for _, stat := range statOrder {
+		ivs[stat] = r.roll(32)
+	}
+	return ivs
+}
+
+// rollAbility picks one of the species' regular abilities, or rarely its hidden one
+func rollAbility(pokemonData PokeData, r roller) (string, int, bool) {
+	regular, hidden := []int{}, -1
+	for i, a := range pokemonData.Abilities {
+		if a.IsHidden {
+			hidden = i
+		} else {
+			regular = append(regular, i)
+		}
+	}
+
+	pick := -1
+	if hidden >= 0 && (len(regular) == 0 || r.roll(hiddenAbilityOdds) == 0) {
+		pick = hidden
+	} else if len(regular) > 0 {
+		pick = regular[r.roll(len(regular))]
+	}
+
+	if pick < 0 {
+		return "", 0, false
+	}
+	a := pokemonData.Abilities[pick]
+	return a.Ability.Name, a.Slot, a.IsHidden
+}
+
+// abilityForSlot keeps an ability slot across evolution
+func abilityForSlot(pokemonData PokeData, slot int) string {
+	for _, a := range pokemonData.Abilities {
+		if a.Slot == slot {
+			return a.Ability.Name
+		}
+	}
+	return ""
+}
+
+// rollGender uses the species gender_rate: -1 is genderless, otherwise
+// the chance of being female in eighths
+func rollGender(genderRate int, r roller) string {
+	if genderRate < 0 {
+		return "genderless"
+	}
+	if r.roll(8) < genderRate {
+		return "female"
+	}
+	return "male"
+}
+
+// rollIndividual gives a fresh catch everything that makes it unique
+func rollIndividual(param *config, pokemon *CaughtPokemon, species PokeSpecies) error {
+	r := param.Roll
+	pokemon.IVs = rollIVs(r)
+	pokemon.Ability, pokemon.AbilitySlot, pokemon.HiddenAbility = rollAbility(pokemon.Data, r)
+	pokemon.Gender = rollGender(species.GenderRate, r)
+	pokemon.Shiny = param.ShinyOdds > 0 && r.roll(param.ShinyOdds) == 0
+
+	// A nature that fails to load leaves the catch neutral rather than losing it
+	nature, err := fetchNature(param, r.roll(natureCount)+1)
+	pokemon.Nature = nature
+	pokemon.recalcStats()
+	return err
+}
+
+// spriteURL picks the front sprite that matches a caught pokemon
+func spriteURL(pokemon *CaughtPokemon) string {
+	return pickSprite(pokemon.Data, spriteVariant{Shiny: pokemon.Shiny, Female: pokemon.Gender == "female"})
+}
+
+func commandShinyOdds(param *config) error {
+	if len(param.Args) == 0 {
+		fmt.Printf("Shiny odds: 1 in %d\n", param.ShinyOdds)
+		return nil
+	}
+
+	odds, err := strconv.Atoi(param.Args[0])
+	if err != nil || odds < 1 {
+		fmt.Println("Shiny odds have to be a whole number like 4096")
+		return nil
+	}
+
+	param.ShinyOdds = odds
+	fmt.Printf("Shiny odds set to 1 in %d\n", odds)
+	return nil
+}
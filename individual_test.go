@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNatureModifier(t *testing.T) {
+	adamant := Nature{Name: "adamant", Increased: "attack", Decreased: "special-attack"}
+	hardy := Nature{Name: "hardy"}
+
+	cases := []struct {
+		nature   Nature
+		stat     string
+		expected float64
+	}{
+		{nature: adamant, stat: "attack", expected: 1.1},
+		{nature: adamant, stat: "special-attack", expected: 0.9},
+		{nature: adamant, stat: "speed", expected: 1},
+		{nature: hardy, stat: "attack", expected: 1},
+		{nature: Nature{}, stat: "", expected: 1},
+	}
+
+	for _, c := range cases {
+		if actual := c.nature.modifier(c.stat); actual != c.expected {
+			t.Errorf("For %v on %v, expected %v but got %v", c.nature.Name, c.stat, c.expected, actual)
+		}
+	}
+}
+
+func TestRollAbility(t *testing.T) {
+	var pokemonData PokeData
+	fixture := `{"abilities": [
+		{"ability": {"name": "static"}, "is_hidden": false, "slot": 1},
+		{"ability": {"name": "lightning-rod"}, "is_hidden": true, "slot": 3}
+	]}`
+	if err := json.Unmarshal([]byte(fixture), &pokemonData); err != nil {
+		t.Fatalf("could not decode fixture: %v", err)
+	}
+
+	cases := []struct {
+		roll     int
+		expected string
+		hidden   bool
+	}{
+		{roll: 0, expected: "lightning-rod", hidden: true},
+		{roll: 1, expected: "static", hidden: false},
+	}
+
+	for _, c := range cases {
+		roll := c.roll
+		rolls := 0
+		name, _, hidden := rollAbility(pokemonData, func(n int) int {
+			rolls++
+			if rolls == 1 {
+				return roll
+			}
+			return 0
+		})
+		if name != c.expected || hidden != c.hidden {
+			t.Errorf("For roll %v, expected %v (hidden %v) but got %v (hidden %v)", c.roll, c.expected, c.hidden, name, hidden)
+		}
+	}
+}
+
+func TestRollGender(t *testing.T) {
+	cases := []struct {
+		genderRate int
+		roll       int
+		expected   string
+	}{
+		{genderRate: -1, roll: 0, expected: "genderless"},
+		{genderRate: 0, roll: 0, expected: "male"},
+		{genderRate: 8, roll: 7, expected: "female"},
+		{genderRate: 1, roll: 0, expected: "female"},
+		{genderRate: 1, roll: 1, expected: "male"},
+	}
+
+	for _, c := range cases {
+		roll := c.roll
+		if actual := rollGender(c.genderRate, func(n int) int { return roll }); actual != c.expected {
+			t.Errorf("For gender rate %v and roll %v, expected %v but got %v", c.genderRate, c.roll, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// A Master Ball never fails, so any multiplier at or above this is a sure catch
+const masterBallMultiplier = 255
+
+var ballOrder = []string{"poke", "great", "ultra", "master"}
+
+var ballMultipliers = map[string]float64{
+	"poke":   1,
+	"great":  1.5,
+	"ultra":  2,
+	"master": masterBallMultiplier,
+}
+
+type NamedAPIResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type PokeItem struct {
+	ID            int              `json:"id"`
+	Name          string           `json:"name"`
+	Cost          int              `json:"cost"`
+	Category      NamedAPIResource `json:"category"`
+	EffectEntries []struct {
+		Effect      string           `json:"effect"`
+		ShortEffect string           `json:"short_effect"`
+		Language    NamedAPIResource `json:"language"`
+	} `json:"effect_entries"`
+	FlavorTextEntries []struct {
+		Text         string           `json:"text"`
+		Language     NamedAPIResource `json:"language"`
+		VersionGroup NamedAPIResource `json:"version_group"`
+	} `json:"flavor_text_entries"`
+	Names []LocalizedName `json:"names"`
+}
+
+type Ball struct {
+	Key         string
+	Name        string
+	Description string
+	Multiplier  float64
+}
+
+func startingInventory() map[string]int {
+	return map[string]int{
+		"poke":   20,
+		"great":  10,
+		"ultra":  5,
+		"master": 1,
+	}
+}
+
+// normalizeBallKey accepts "ultra", "ultra-ball" or "ultraball"
+func normalizeBallKey(name string) string {
+	name = strings.ToLower(name)
+	name = strings.TrimSuffix(name, "ball")
+	name = strings.TrimSuffix(name, "-")
+	if name == "poké" {
+		return "poke"
+	}
+	return name
+}
+
+func fetchItem(param *config, name string) (PokeItem, error) {
+	var item PokeItem
+
+	pokeUrl := fmt.Sprintf("https://pokeapi.co/api/v2/item/%v", name)
+	body, err := fetchData(param, pokeUrl)
+	if err != nil {
+		return item, err
+	}
+
+	if err := json.Unmarshal(body, &item); err != nil {
+		return item, err
+	}
+	return item, nil
+}
+
+func (item PokeItem) displayName() string {
+	for _, n := range item.Names {
+		if n.Language.Name == "en" {
+			return n.Name
+		}
+	}
+	return item.Name
+}
+
+func (item PokeItem) description() string {
+	for _, e := range item.EffectEntries {
+		if e.Language.Name == "en" {
+			return e.ShortEffect
+		}
+	}
+	for _, f := range item.FlavorTextEntries {
+		if f.Language.Name == "en" {
+			return strings.Join(strings.Fields(f.Text), " ")
+		}
+	}
+	return ""
+}
+
+func fetchBall(param *config, key string) (Ball, error) {
+	item, err := fetchItem(param, key+"-ball")
+	if err != nil {
+		return Ball{}, err
+	}
+
+	return Ball{
+		Key:         key,
+		Name:        item.displayName(),
+		Description: item.description(),
+		Multiplier:  ballMultipliers[key],
+	}, nil
+}
+
+func commandInventory(param *config) error {
+	fmt.Println("Your Poké Balls:")
+
+	for _, key := range ballOrder {
+		ball, err := fetchBall(param, key)
+		if err != nil {
+			fmt.Println("Error loading the ball from pokeAPI")
+			return err
+		}
+
+		fmt.Printf(" - %v x%d (catch bonus %gx)\n", ball.Name, param.Inventory[key], ball.Multiplier)
+		if ball.Description != "" {
+			fmt.Printf("     %v\n", ball.Description)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package main
+
+import "testing"
+
+func TestNormalizeBallKey(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "ultra", expected: "ultra"},
+		{input: "ultra-ball", expected: "ultra"},
+		{input: "UltraBall", expected: "ultra"},
+		{input: "great-ball", expected: "great"},
+		{input: "poke", expected: "poke"},
+		{input: "poké-ball", expected: "poke"},
+		{input: "master", expected: "master"},
+	}
+
+	for _, c := range cases {
+		if actual := normalizeBallKey(c.input); actual != c.expected {
+			t.Errorf("For %q, expected %q but got %q", c.input, c.expected, actual)
+		}
+	}
+}
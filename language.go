@@ -0,0 +1,232 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// languages are the PokeAPI language names that have translations
+var languages = []string{"en", "ja", "ja-Hrkt", "roomaji", "ko", "zh-Hant", "zh-Hans", "fr", "de", "es", "it"}
+
+// LocalizedName is one translation from the "names" list PokeAPI resources carry
+type LocalizedName struct {
+	Name     string           `json:"name"`
+	Language NamedAPIResource `json:"language"`
+}
+
+// localize picks the name in a language, falling back to English and then to slug
+func localize(names []LocalizedName, language, slug string) string {
+	english := ""
+	for _, n := range names {
+		switch {
+		case strings.EqualFold(n.Language.Name, language):
+			return n.Name
+		case n.Language.Name == defaultLanguage:
+			english = n.Name
+		}
+	}
+
+	if english != "" {
+		return english
+	}
+	return slug
+}
+
+// fetchNames loads just the translations of any named PokeAPI resource,
+// e.g. fetchNames(param, "pokemon-species", "bulbasaur")
+func fetchNames(param *config, endpoint, slug string) ([]LocalizedName, error) {
+	var resource struct {
+		Names []LocalizedName `json:"names"`
+	}
+
+	body, err := fetchData(param, fmt.Sprintf("https://pokeapi.co/api/v2/%v/%v", endpoint, slug))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(body, &resource); err != nil {
+		return nil, err
+	}
+	return resource.Names, nil
+}
+
+// localName is slug in the selected language, or the slug itself when no
+// language is selected or the lookup fails. Every name shown is remembered
+// so it can be typed back in place of the slug.
+func localName(param *config, endpoint, slug string) string {
+	if param.Language == "" || slug == "" {
+		return slug
+	}
+
+	names, err := fetchNames(param, endpoint, slug)
+	if err != nil {
+		return slug
+	}
+
+	name := localize(names, param.Language, slug)
+	rememberName(param, endpoint, name, slug)
+	return name
+}
+
+// localLabel is localName followed by the slug, for input that needs the slug
+func localLabel(param *config, endpoint, slug string) string {
+	name := localName(param, endpoint, slug)
+	if name == slug {
+		return slug
+	}
+	return fmt.Sprintf("%v (%v)", name, slug)
+}
+
+// rememberName lets a localized name be typed in place of its slug. Names
+// are kept per endpoint, since a town and its area often share one.
+func rememberName(param *config, endpoint, name, slug string) {
+	if param.LocalNames == nil {
+		param.LocalNames = make(map[string]string)
+	}
+	param.LocalNames[endpoint+"/"+strings.ToLower(name)] = slug
+}
+
+// rememberedName is the slug of a localized name seen in any of endpoints
+func rememberedName(param *config, input string, endpoints []string) (string, bool) {
+	for _, endpoint := range endpoints {
+		if slug, ok := param.LocalNames[endpoint+"/"+strings.ToLower(input)]; ok {
+			return slug, true
+		}
+	}
+	return "", false
+}
+
+// resolveName turns a localized name back into a slug of one of endpoints,
+// anything else is returned as it was typed. Only names already shown, or
+// indexed with language index, are known.
+func resolveName(param *config, input string, endpoints ...string) string {
+	if slug, ok := rememberedName(param, input, endpoints); ok {
+		return slug
+	}
+	return input
+}
+
+// reportUnknown says input is neither a slug of endpoint nor a name shown so far
+func reportUnknown(param *config, input, kind, endpoint string) {
+	fmt.Printf("Unknown %v %v\n", kind, input)
+	if param.Language != "" && !param.IndexedNames[endpoint] {
+		fmt.Printf("Names can be typed once they've been shown, or after language index %v\n", endpoint)
+	}
+}
+
+func fetchResourceList(param *config, endpoint string) ([]NamedAPIResource, error) {
+	var list namedResourceList
+
+	body, err := fetchData(param, fmt.Sprintf("https://pokeapi.co/api/v2/%v/?limit=10000", endpoint))
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, err
+	}
+	return list.Results, nil
+}
+
+// indexableEndpoints are the endpoints whose names can be typed as input
+var indexableEndpoints = []string{"pokemon-species", "location", "location-area", "region"}
+
+// indexNames remembers every translation of every resource of an endpoint.
+// It takes one request per resource, so it only runs when asked for.
+func indexNames(param *config, endpoint string, list []NamedAPIResource) error {
+	fmt.Printf("Looking up %d %v names...\n", len(list), endpoint)
+	for _, resource := range list {
+		names, err := fetchNames(param, endpoint, resource.Name)
+		if err != nil {
+			return err
+		}
+		for _, n := range names {
+			rememberName(param, endpoint, n.Name, resource.Name)
+		}
+	}
+
+	if param.IndexedNames == nil {
+		param.IndexedNames = make(map[string]bool)
+	}
+	param.IndexedNames[endpoint] = true
+	return nil
+}
+
+// textLanguage is the language for descriptions and flavor text
+func textLanguage(param *config) string {
+	if param.Language == "" {
+		return defaultLanguage
+	}
+	return param.Language
+}
+
+func commandLanguage(param *config) error {
+	if len(param.Args) == 0 {
+		if param.Language == "" {
+			fmt.Println("No language selected, showing names as PokeAPI slugs")
+		} else {
+			fmt.Printf("Current language: %v\n", param.Language)
+		}
+		fmt.Printf("Available: %v\n", strings.Join(languages, ", "))
+		return nil
+	}
+
+	if param.Args[0] == "index" {
+		return commandIndexNames(param)
+	}
+
+	if param.Args[0] == "off" || param.Args[0] == "slug" {
+		param.Language = ""
+		fmt.Println("Showing names as PokeAPI slugs")
+		return nil
+	}
+
+	for _, language := range languages {
+		if strings.EqualFold(language, param.Args[0]) {
+			param.Language = language
+			fmt.Printf("Language set to %v\n", language)
+			return nil
+		}
+	}
+
+	fmt.Printf("Unknown language %v, pick one of: %v\n", param.Args[0], strings.Join(languages, ", "))
+	return nil
+}
+
+// localDisplayName is displayName with the species in the selected language
+func localDisplayName(param *config, pokemon *CaughtPokemon) string {
+	if param.Language == "" {
+		return pokemon.displayName()
+	}
+
+	species := pokemon.Data.Species.Name
+	if species == "" {
+		species = pokemon.Species
+	}
+
+	local := *pokemon
+	local.Species = localName(param, "pokemon-species", species)
+	return local.displayName()
+}
+
+// commandIndexNames looks up every name of an endpoint up front, so names
+// that haven't been shown yet can be typed too
+func commandIndexNames(param *config) error {
+	if len(param.Args) < 2 || !containsString(indexableEndpoints, param.Args[1]) {
+		fmt.Printf("Usage: language index <%v>\n", strings.Join(indexableEndpoints, "|"))
+		return nil
+	}
+	endpoint := param.Args[1]
+
+	list, err := fetchResourceList(param, endpoint)
+	if err != nil {
+		fmt.Printf("Error loading the %v list from pokeAPI\n", endpoint)
+		return err
+	}
+	if err := indexNames(param, endpoint, list); err != nil {
+		fmt.Printf("Error loading the %v names from pokeAPI\n", endpoint)
+		return err
+	}
+	fmt.Printf("Every %v name can be typed now\n", endpoint)
+	return nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestLocalize(t *testing.T) {
+	var names []LocalizedName
+	fixture := `[
+		{"name": "フシギダネ", "language": {"name": "ja-Hrkt"}},
+		{"name": "Bisasam", "language": {"name": "de"}},
+		{"name": "Bulbasaur", "language": {"name": "en"}}
+	]`
+	if err := json.Unmarshal([]byte(fixture), &names); err != nil {
+		t.Fatalf("could not decode fixture: %v", err)
+	}
+
+	cases := []struct {
+		names    []LocalizedName
+		language string
+		expected string
+	}{
+		{names: names, language: "de", expected: "Bisasam"},
+		{names: names, language: "ja-hrkt", expected: "フシギダネ"},
+		{names: names, language: "fr", expected: "Bulbasaur"},
+		{names: nil, language: "fr", expected: "bulbasaur"},
+	}
+
+	for _, c := range cases {
+		if actual := localize(c.names, c.language, "bulbasaur"); actual != c.expected {
+			t.Errorf("In %q, expected %q but got %q", c.language, c.expected, actual)
+		}
+	}
+}
+
+func TestResolveName(t *testing.T) {
+	param := &config{}
+	rememberName(param, "pokemon-species", "Bisasam", "bulbasaur")
+	rememberName(param, "location-area", "Route 201", "sinnoh-route-201-area")
+
+	cases := []struct {
+		input    string
+		endpoint string
+		expected string
+	}{
+		{input: "bisasam", endpoint: "pokemon-species", expected: "bulbasaur"},
+		{input: "route 201", endpoint: "location-area", expected: "sinnoh-route-201-area"},
+		{input: "route 201", endpoint: "location", expected: "route 201"},
+		{input: "bulbasaur", endpoint: "pokemon-species", expected: "bulbasaur"},
+		{input: "pikachu", endpoint: "pokemon-species", expected: "pikachu"},
+	}
+
+	for _, c := range cases {
+		if actual := resolveName(param, c.input, c.endpoint); actual != c.expected {
+			t.Errorf("For %q in %v, expected %q but got %q", c.input, c.endpoint, c.expected, actual)
+		}
+	}
+}
+
+func TestResolveNameNotShownYet(t *testing.T) {
+	param := &config{
+		Language: "de",
+		Cache: seedCache(map[string]string{
+			"https://pokeapi.co/api/v2/pokemon-species/?limit=10000": `{"count": 2, "results": [{"name": "bulbasaur"}, {"name": "ivysaur"}]}`,
+			"https://pokeapi.co/api/v2/pokemon-species/bulbasaur":    `{"names": [{"name": "Bisasam", "language": {"name": "de"}}, {"name": "Bulbasaur", "language": {"name": "en"}}]}`,
+			"https://pokeapi.co/api/v2/pokemon-species/ivysaur":      `{"names": [{"name": "Bisaknosp", "language": {"name": "de"}}]}`,
+		}),
+	}
+
+	// Names that haven't been shown are unknown until the trainer asks for an index
+	if actual := resolveName(param, "bisaknosp", "pokemon-species"); actual != "bisaknosp" || len(param.LocalNames) != 0 {
+		t.Errorf("expected bisaknosp to stay unknown without indexing, got %q", actual)
+	}
+
+	param.Args = []string{"index", "pokemon-species"}
+	if err := commandLanguage(param); err != nil {
+		t.Fatalf("could not index names: %v", err)
+	}
+	if !param.IndexedNames["pokemon-species"] {
+		t.Errorf("expected the species names to be indexed")
+	}
+	if actual := resolveName(param, "bisaknosp", "pokemon-species"); actual != "ivysaur" {
+		t.Errorf("expected bisaknosp to resolve to ivysaur, got %q", actual)
+	}
+	if actual := resolveName(param, "bulbasaur", "pokemon-species"); actual != "bulbasaur" {
+		t.Errorf("expected bulbasaur to resolve to bulbasaur, got %q", actual)
+	}
+	if actual := resolveName(param, "misspelt", "pokemon-species"); actual != "misspelt" {
+		t.Errorf("expected an unknown name to be returned as typed, got %q", actual)
+	}
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const maxKnownMoves = 4
+
+var learnMethodOrder = []string{"level-up", "machine", "egg", "tutor"}
+
+type learnableMove struct {
+	Name   string
+	Method string
+	Level  int // Only set for level-up moves
+}
+
+// idFromURL reads the trailing ID of a PokeAPI resource URL, 0 if there is none
+func idFromURL(url string) int {
+	parts := strings.Split(strings.TrimSuffix(url, "/"), "/")
+	id, _ := strconv.Atoi(parts[len(parts)-1])
+	return id
+}
+
+// latestVersionGroup is the newest game a pokemon has move data for
+func latestVersionGroup(pokemonData PokeData) string {
+	latest, latestID := "", 0
+	for _, move := range pokemonData.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if id := idFromURL(detail.VersionGroup.URL); id > latestID {
+				latest, latestID = detail.VersionGroup.Name, id
+			}
+		}
+	}
+	return latest
+}
+
+// moveVersionGroup is the selected game's version group, or the newest one
+// with data when no version is selected
+func moveVersionGroup(param *config, pokemonData PokeData) string {
+	if param.VersionGroup != "" {
+		return param.VersionGroup
+	}
+	return latestVersionGroup(pokemonData)
+}
+
+// learnset groups every move a pokemon can learn in a version group by method
+func learnset(pokemonData PokeData, versionGroup string) map[string][]learnableMove {
+	groups := make(map[string][]learnableMove)
+
+	for _, move := range pokemonData.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if detail.VersionGroup.Name != versionGroup {
+				continue
+			}
+
+			method := detail.MoveLearnMethod.Name
+			groups[method] = append(groups[method], learnableMove{
+				Name:   move.Move.Name,
+				Method: method,
+				Level:  detail.LevelLearnedAt,
+			})
+		}
+	}
+
+	for method := range groups {
+		moves := groups[method]
+		sort.SliceStable(moves, func(i, j int) bool {
+			if moves[i].Level != moves[j].Level {
+				return moves[i].Level < moves[j].Level
+			}
+			return moves[i].Name < moves[j].Name
+		})
+	}
+	return groups
+}
+
+// checkLearn explains why a caught pokemon can't learn a move, or returns
+// how it learns it. Machine moves still need the TM or HM, tutor moves are free.
+func checkLearn(pokemon *CaughtPokemon, move string, versionGroup string) (string, error) {
+	if containsString(pokemon.Moves, move) {
+		return "", fmt.Errorf("%v already knows %v", pokemon.displayName(), move)
+	}
+
+	ways := []string{}
+	byMachine := false
+	for method, moves := range learnset(pokemon.Data, versionGroup) {
+		for _, m := range moves {
+			if m.Name != move {
+				continue
+			}
+
+			switch method {
+			case "level-up":
+				if pokemon.Level >= m.Level {
+					return method, nil
+				}
+				ways = append(ways, fmt.Sprintf("it learns it at level %d", m.Level))
+			case "egg":
+				ways = append(ways, "it's an egg move, only passed down when hatching")
+			case "machine":
+				byMachine = true
+			default:
+				return method, nil
+			}
+		}
+	}
+
+	if byMachine {
+		return "machine", nil
+	}
+	if len(ways) == 0 {
+		return "", fmt.Errorf("%v can't learn %v in %v", pokemon.Species, move, versionGroup)
+	}
+	return "", fmt.Errorf("%v can't learn %v yet: %v", pokemon.displayName(), move, strings.Join(ways, ", "))
+}
+
+// PokeMachine is a TM or HM, the item that teaches one move in a version group
+type PokeMachine struct {
+	ID           int              `json:"id"`
+	Item         NamedAPIResource `json:"item"`
+	Move         NamedAPIResource `json:"move"`
+	VersionGroup NamedAPIResource `json:"version_group"`
+}
+
+// machineItem is the TM or HM that teaches a move in a version group, e.g. tm24
+func machineItem(param *config, moveName string, versionGroup string) (string, error) {
+	move, err := fetchMove(param, moveName)
+	if err != nil {
+		return "", err
+	}
+
+	for _, m := range move.Machines {
+		if m.VersionGroup.Name != versionGroup {
+			continue
+		}
+
+		var machine PokeMachine
+		body, err := fetchData(param, m.Machine.URL)
+		if err != nil {
+			return "", err
+		}
+		if err := json.Unmarshal(body, &machine); err != nil {
+			return "", err
+		}
+		return machine.Item.Name, nil
+	}
+	return "", fmt.Errorf("no machine teaches %v in %v", moveName, versionGroup)
+}
+
+// startingMoves are the last four level-up moves a wild pokemon would know
+func startingMoves(pokemonData PokeData, level int, versionGroup string) []string {
+	moves := []string{}
+	for _, m := range levelUpMoves(pokemonData, level, versionGroup) {
+		if len(moves) == maxKnownMoves {
+			break
+		}
+		if !containsString(moves, m.Name) {
+			moves = append(moves, m.Name)
+		}
+	}
+	return moves
+}
+
+func commandMoves(param *config) error {
+	if len(param.Args) == 0 {
+		fmt.Println("Which pokemon's moves should be shown?")
+		return nil
+	}
+
+	var known []string
+	pokemonData, err := lookupPokemon(param, param.Args[0])
+	if err != nil {
+		fmt.Println("Error finding the pokemon, Make sure it exists")
+		return err
+	}
+	if pokemon, err := findCaught(param, param.Args[0]); err == nil {
+		known = pokemon.Moves
+		fmt.Printf("%v knows: %v\n", pokemon.displayName(), strings.Join(known, ", "))
+	}
+
+	versionGroup := moveVersionGroup(param, pokemonData)
+	groups := learnset(pokemonData, versionGroup)
+	if len(groups) == 0 {
+		fmt.Printf("%v has no moves in %v\n", pokemonData.Name, orUnknown(versionGroup))
+		return nil
+	}
+
+	methods := append([]string{}, learnMethodOrder...)
+	for method := range groups {
+		if !containsString(methods, method) {
+			methods = append(methods, method)
+		}
+	}
+
+	fmt.Printf("Moves %v can learn in %v:\n", pokemonData.Name, versionGroup)
+	for _, method := range methods {
+		if len(groups[method]) == 0 {
+			continue
+		}
+
+		fmt.Printf("%v:\n", method)
+		for _, m := range groups[method] {
+			marker := " "
+			if containsString(known, m.Name) {
+				marker = "*"
+			}
+			if method == "level-up" {
+				fmt.Printf(" %v Lv%-3d %v\n", marker, m.Level, m.Name)
+			} else {
+				fmt.Printf(" %v %v\n", marker, m.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func commandLearn(param *config) error {
+	positional, flags := parseArgs(param.Args)
+	if len(positional) < 2 {
+		fmt.Println("Usage: learn <pokemon> <move> [--replace <move>]")
+		return nil
+	}
+
+	pokemon, err := findCaught(param, positional[0])
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+	if err := pokemon.loadData(param); err != nil {
+		fmt.Println("Error loading the pokemon from pokeAPI")
+		return err
+	}
+	move := positional[1]
+
+	versionGroup := moveVersionGroup(param, pokemon.Data)
+	method, err := checkLearn(pokemon, move, versionGroup)
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+
+	// Machines are kept after use, but the trainer has to have one
+	if method == "machine" && !param.Sandbox {
+		item, err := machineItem(param, move, versionGroup)
+		if err != nil {
+			fmt.Println("Error loading the machine from pokeAPI")
+			return err
+		}
+		if param.Bag[item] <= 0 {
+			fmt.Printf("%v is taught with %v, which isn't in your bag\n", move, item)
+			return nil
+		}
+	}
+
+	old, replacing := flags["replace"]
+	if !replacing {
+		if len(pokemon.Moves) >= maxKnownMoves {
+			fmt.Printf("%v already knows %d moves: %v\n", pokemon.displayName(), maxKnownMoves, strings.Join(pokemon.Moves, ", "))
+			fmt.Println("Use --replace <move> to forget one of them")
+			return nil
+		}
+		pokemon.Moves = append(pokemon.Moves, move)
+		fmt.Printf("%v learned %v!\n", pokemon.displayName(), move)
+		return nil
+	}
+
+	if !containsString(pokemon.Moves, old) {
+		fmt.Printf("%v doesn't know %v\n", pokemon.displayName(), old)
+		return nil
+	}
+	for i := range pokemon.Moves {
+		if pokemon.Moves[i] == old {
+			pokemon.Moves[i] = move
+		}
+	}
+	fmt.Printf("1, 2, and... Poof! %v forgot %v and learned %v!\n", pokemon.displayName(), old, move)
+	return nil
+}
+
+func commandForget(param *config) error {
+	if len(param.Args) < 2 {
+		fmt.Println("Usage: forget <pokemon> <move>")
+		return nil
+	}
+
+	pokemon, err := findCaught(param, param.Args[0])
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+
+	if !containsString(pokemon.Moves, param.Args[1]) {
+		fmt.Printf("%v doesn't know %v\n", pokemon.displayName(), param.Args[1])
+		return nil
+	}
+	if len(pokemon.Moves) == 1 {
+		fmt.Printf("%v has to know at least one move\n", pokemon.displayName())
+		return nil
+	}
+
+	kept := []string{}
+	for _, known := range pokemon.Moves {
+		if known != param.Args[1] {
+			kept = append(kept, known)
+		}
+	}
+	pokemon.Moves = kept
+	fmt.Printf("%v forgot %v\n", pokemon.displayName(), param.Args[1])
+	return nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const maxLevel = 100
+
+type PokeGrowthRate struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Levels []struct {
+		Level      int `json:"level"`
+		Experience int `json:"experience"`
+	} `json:"levels"`
+}
+
+func fetchGrowthRate(param *config, name string) (PokeGrowthRate, error) {
+	var growth PokeGrowthRate
+
+	body, err := fetchData(param, fmt.Sprintf("https://pokeapi.co/api/v2/growth-rate/%v", name))
+	if err != nil {
+		return growth, err
+	}
+
+	if err := json.Unmarshal(body, &growth); err != nil {
+		return growth, err
+	}
+	return growth, nil
+}
+
+// experienceForLevel is the total experience needed to reach a level
+func (g PokeGrowthRate) experienceForLevel(level int) int {
+	for _, l := range g.Levels {
+		if l.Level == level {
+			return l.Experience
+		}
+	}
+	return 0
+}
+
+// levelForExperience is the highest level reached with this much experience
+func (g PokeGrowthRate) levelForExperience(experience int) int {
+	level := 1
+	for _, l := range g.Levels {
+		if l.Experience <= experience && l.Level > level {
+			level = l.Level
+		}
+	}
+	return level
+}
+
+// growthRate loads the curve a caught pokemon levels along, remembering
+// its name on the pokemon so the species only has to be looked up once.
+func growthRate(param *config, pokemon *CaughtPokemon) (PokeGrowthRate, error) {
+	if pokemon.GrowthRate == "" {
+		if err := pokemon.loadData(param); err != nil {
+			return PokeGrowthRate{}, err
+		}
+		species, err := fetchSpecies(param, pokemon.Data)
+		if err != nil {
+			return PokeGrowthRate{}, err
+		}
+		pokemon.GrowthRate = species.GrowthRate.Name
+	}
+	return fetchGrowthRate(param, pokemon.GrowthRate)
+}
+
+// experienceYield is the Generation I-IV formula, trainer battles give 1.5x
+func experienceYield(baseExperience, level int, trainer bool) int {
+	experience := baseExperience * level / 7
+	if trainer {
+		experience = experience * 3 / 2
+	}
+	return max(1, experience)
+}
+
+// gainExperience adds experience and EVs to a caught pokemon and levels it up
+func gainExperience(param *config, pokemon *CaughtPokemon, experience int, evYield map[string]int) error {
+	if err := pokemon.loadData(param); err != nil {
+		return err
+	}
+	if pokemon.EVs == nil {
+		pokemon.EVs = make(map[string]int)
+	}
+	addEVs(pokemon.EVs, evYield)
+
+	growth, err := growthRate(param, pokemon)
+	if err != nil {
+		return err
+	}
+
+	// Pokemon caught before experience was tracked start at the bottom of their level
+	if pokemon.Experience < growth.experienceForLevel(pokemon.Level) {
+		pokemon.Experience = growth.experienceForLevel(pokemon.Level)
+	}
+
+	pokemon.Experience += experience
+	fmt.Printf("%v gained %d experience points!\n", pokemon.displayName(), experience)
+
+	newLevel := min(maxLevel, growth.levelForExperience(pokemon.Experience))
+	for pokemon.Level < newLevel {
+		pokemon.Level++
+		pokemon.Friendship = min(255, pokemon.Friendship+friendshipGain(pokemon.Friendship))
+		fmt.Printf("%v grew to level %d!\n", pokemon.displayName(), pokemon.Level)
+		learnLevelUpMoves(param, pokemon)
+	}
+
+	pokemon.recalcStats()
+	return nil
+}
+
+// learnLevelUpMoves teaches the moves learned at the pokemon's new level,
+// or points at the learn command once it already knows four
+func learnLevelUpMoves(param *config, pokemon *CaughtPokemon) {
+	for _, m := range learnset(pokemon.Data, moveVersionGroup(param, pokemon.Data))["level-up"] {
+		if m.Level != pokemon.Level || containsString(pokemon.Moves, m.Name) {
+			continue
+		}
+
+		if len(pokemon.Moves) < maxKnownMoves {
+			pokemon.Moves = append(pokemon.Moves, m.Name)
+			fmt.Printf("%v learned %v!\n", pokemon.displayName(), m.Name)
+		} else {
+			fmt.Printf("%v wants to learn %v, use learn --replace to make room\n", pokemon.displayName(), m.Name)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+func fetchLocationArea(param *config, area string) (PokeLocation, error) {
+	var location PokeLocation
+
+	pokeUrl := fmt.Sprintf("https://pokeapi.co/api/v2/location-area/%v", area)
+	body, err := fetchData(param, pokeUrl)
+	if err != nil {
+		return location, err
+	}
+
+	if err := json.Unmarshal(body, &location); err != nil {
+		return location, err
+	}
+	return location, nil
+}
+
+// availableSpecies lists the pokemon that can be encountered in an area,
+// limited to one game version unless version is empty.
+func availableSpecies(area PokeLocation, version string) []string {
+	names := []string{}
+	for _, encounter := range area.PokemonEncounters {
+		for _, versionDetail := range encounter.VersionDetails {
+			if version == "" || versionDetail.Version.Name == version {
+				names = append(names, encounter.Pokemon.Name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+func commandSandbox(param *config) error {
+	param.Sandbox = !param.Sandbox
+
+	if param.Sandbox {
+		fmt.Println("Sandbox mode on: you can catch pokemon from anywhere")
+	} else {
+		fmt.Println("Sandbox mode off: you can only catch pokemon living in your current area")
+	}
+	return nil
+}
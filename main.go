@@ -10,7 +10,6 @@ import (
 	"encoding/json"
 	"time"
 	"github.com/girik21/pokedexcli/internal/pokecache"
-	"math/rand"
 )
 
 type config struct {
@@ -18,9 +17,36 @@ type config struct {
 	Previous string
 	Location string
 	Encounter string // Name of the pokemon
-	Caught map[string]PokeData
+	Caught map[int]*CaughtPokemon // Every pokemon the trainer owns, keyed by instance ID
+	NextID int
 	Cache    *pokecache.Cache
 	Inspect string
+	Args []string // Words typed after the command name
+	Inventory map[string]int // Poké Ball key -> how many the trainer has left
+	CatchModel CatchModel
+	CurrentArea *PokeLocation // Set by explore and travel, catch is limited to what lives here
+	Sandbox bool // Lets catch ignore the current location
+	Wild *WildPokemon // The pokemon met by the last encounter, if any
+	Version string // Selected game, empty means data from every game
+	VersionGroup string
+	Generation int
+	Party []int // Up to six IDs from Caught, the first one leads
+	Boxes []PCBox // PC storage for every caught pokemon not in the party
+	Battle *Battle // The battle in progress, if any
+	TypeChart *TypeChart // Loaded from PokeAPI the first time it's needed
+	RegionDexes []regionDex // Loaded from PokeAPI the first time nationaldex runs
+	ShinyOdds int // A catch is shiny with a chance of 1 in ShinyOdds
+	Roll roller // Rolls what makes each catch unique, nil uses math/rand
+	Bag map[string]int // Item name -> how many the trainer has, besides Poké Balls
+	Language string // PokeAPI language for display names, empty shows slugs
+	Region string // Selected region, map only pages through its locations
+	RegionPage int // Page of the region last shown by map, 0 before the first
+	Position string // The location the trainer is at, explore is limited to its areas
+	Seen map[int]bool // National dex numbers met in explore or encounters
+	CaughtDex map[int]bool // National dex numbers ever caught, kept after evolving
+	LocalNames map[string]string // Lowercased localized names shown or looked up so far -> slug
+	IndexedNames map[string]bool // Endpoints whose names are all in LocalNames
+	SavePath string
 }
 
 type cliCommand struct {
@@ -49,14 +75,8 @@ type PokeLocation struct {
 		Name string `json:"name"`
 		URL  string `json:"url"`
 	} `json:"location"`
-	Name  string `json:"name"`
-	Names []struct {
-		Language struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"language"`
-		Name string `json:"name"`
-	} `json:"names"`
+	Name              string          `json:"name"`
+	Names             []LocalizedName `json:"names"`
 	PokemonEncounters []struct {
 		Pokemon struct {
 			Name string `json:"name"`
@@ -393,7 +413,35 @@ func savePokemon(pokemonData PokeData) SavedPokemon {
     }
 }
 
+// fetchData GETs a PokeAPI url, going through the cache first.
+func fetchData(param *config, pokeUrl string) ([]byte, error) {
+	if data, ok := param.Cache.Get(pokeUrl); ok {
+		return data, nil
+	}
+
+	resp, err := http.Get(pokeUrl)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%v returned %v", pokeUrl, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	param.Cache.Add(pokeUrl, body)
+	return body, nil
+}
+
 func commandExit(param *config) error {
+	if err := saveGame(param); err != nil {
+		fmt.Println("Error saving the game:", err)
+	}
 	fmt.Printf("Closing the Pokedex... Goodbye! \n")
 	os.Exit(0)
 	return nil
@@ -406,13 +454,61 @@ func commandHelp(param *config) error {
 	fmt.Printf("\n")
 	fmt.Printf("\n")
 	
-	fmt.Printf("map: shows the map of pokemon\n")
+	fmt.Printf("map: shows the map of pokemon, only the selected region's when there is one\n")
+	fmt.Printf("explore <area>: lists the pokemon in an area of your current location and makes it your area, use travel to go elsewhere\n")
+	fmt.Printf("travel <location|area>: walks to a location through the routes in between, or straight there outside Kanto\n")
+	fmt.Printf("where: shows where you are and where you can go next\n")
+	fmt.Printf("route [from] <to>: shows the shortest way between two locations\n")
+	fmt.Printf("encounter [method] [--version <game>]: meet a wild pokemon in the current area\n")
+	fmt.Printf("walk, surf, fish [old|good|super]: meet a wild pokemon with that method\n")
+	fmt.Printf("catch [pokemon] [--ball poke|great|ultra|master] [--cheat] [--nickname <name>]: throw a Poké Ball, at the wild pokemon by default\n")
+	fmt.Printf("pokedex [--sort id|name|dex|caught|level|total|<stat>] [--desc] [--type <type>] [--gen <n>] [--shiny] [--min-<stat> <n>] [--max-<stat> <n>] [--page <n>] [--per-page <n>]: lists the pokemon you caught\n")
+	fmt.Printf("inspect <pokemon|id|nickname> [--sprite] [--back] [--shiny] [--gen <n>] [--ascii] [--width <n>]: shows a caught pokemon, optionally drawing its sprite\n")
+	fmt.Printf("version [game|any]: shows or selects the game version used to filter data\n")
+	fmt.Printf("sandbox: toggles catching pokemon that don't live in your current area\n")
+	fmt.Printf("inventory: shows the Poké Balls you are carrying\n")
+	fmt.Printf("catchmodel [simple|standard]: shows or switches the catch formula\n")
+	fmt.Printf("party: shows your team of up to six pokemon\n")
+	fmt.Printf("party add|remove|lead <name>, party swap <a> <b>: manages your team\n")
+	fmt.Printf("nickname <pokemon> [name]: names or un-names a caught pokemon\n")
+	fmt.Printf("box: lists the pokemon stored in the PC\n")
+	fmt.Printf("battle [pokemon] [--vs <caught pokemon>]: battles the wild pokemon, or another of yours for practice (no experience)\n")
+	fmt.Printf("fight <move|number>: uses a move in the current battle\n")
+	fmt.Printf("run: flees the current battle\n")
+	fmt.Printf("weakness <pokemon>: shows how much damage each type deals to a pokemon\n")
+	fmt.Printf("damage <attacker> <move> <defender> [--level 50]: calculates min/max damage\n")
+	fmt.Printf("evolution <pokemon>: shows the evolution chain and what triggers each step\n")
+	fmt.Printf("evolve <pokemon> [--item <item>] [--trade]: evolves a caught pokemon when it's ready\n")
+	fmt.Printf("shinyodds [n]: shows or sets the 1 in n chance of a shiny catch\n")
+	fmt.Printf("moves <pokemon>: lists learnable moves by method for the selected version\n")
+	fmt.Printf("learn <pokemon> <move> [--replace <move>]: teaches a move, up to four. TM and HM moves need the machine in your bag, tutor moves are free\n")
+	fmt.Printf("forget <pokemon> <move>: forgets a move\n")
+	fmt.Printf("bag: shows your Poké Balls and items\n")
+	fmt.Printf("give <item> <pokemon>: gives a caught pokemon an item to hold\n")
+	fmt.Printf("take <pokemon>: takes a held item back into the bag\n")
+	fmt.Printf("use <item> [pokemon]: uses a potion in battle, a rare candy or an evolution item\n")
+	fmt.Printf("dex <pokemon> [--version <version>] [--lang <language>]: shows the Pokédex entry for a species\n")
+	fmt.Printf("language [code|off]: shows names in another language, which can be typed as input once shown\n")
+	fmt.Printf("language index <pokemon-species|location|location-area|region>: looks up every name of a kind, so any can be typed\n")
+	fmt.Printf("regions: lists every region\n")
+	fmt.Printf("region [name|all]: shows or selects the region map pages through\n")
+	fmt.Printf("locations: lists the locations in the selected region\n")
+	fmt.Printf("areas <location>: lists the areas you can explore in a location\n")
+	fmt.Printf("where-to-find <pokemon> [--version <version>|any] [--sort area|chance|level]: lists the areas a pokemon lives in\n")
+	fmt.Printf("nationaldex: shows how many species you have seen and caught, by generation and region\n")
+	fmt.Printf("missing [--gen <n>] [--in-version] [--seen] [--page <n>] [--per-page <n>]: lists the species you haven't caught yet\n")
+	fmt.Printf("compare <pokemon> <pokemon> [more...]: compares stats, types and matchups side by side\n")
+	fmt.Printf("save: saves the game (also done on exit)\n")
 	fmt.Printf("help: Displays a help message \n")
 	fmt.Printf("exit: Exit the Pokedex \n")
 	return nil
 }
 
 func commandBack(param *config) error {
+	if param.Region != "" {
+		return mapRegion(param, -1)
+	}
+
 	pokeUrl := "https://pokeapi.co/api/v2/location-area/?limit=20"
 	if param.Previous != "" {
 		pokeUrl = param.Previous
@@ -447,7 +543,7 @@ func commandBack(param *config) error {
 	if output, ok := rawOutput["results"].([]interface{}); ok {
 		for _, item := range output {
 			if location, ok := item.(map[string]interface{}); ok {
-				fmt.Println(location["name"])
+				fmt.Println(localLabel(param, "location-area", fmt.Sprint(location["name"])))
 			}
 		}
 	}
@@ -462,6 +558,10 @@ func commandBack(param *config) error {
 }
 
 func commandMap(param *config) error {
+	if param.Region != "" {
+		return mapRegion(param, 1)
+	}
+
 	pokeUrl := "https://pokeapi.co/api/v2/location-area/?limit=20"
 	if param.Next != "" {
 		pokeUrl = param.Next
@@ -498,7 +598,7 @@ func commandMap(param *config) error {
 	if result, ok := rawOutput["results"].([]interface{}); ok {
 		for _, item := range result {
 			if location, ok := item.(map[string]interface{}); ok {
-				fmt.Println(location["name"])
+				fmt.Println(localLabel(param, "location-area", fmt.Sprint(location["name"])))
 			}
 		}
 	}
@@ -513,40 +613,37 @@ func commandMap(param *config) error {
 }
 
 func commandExplore(param *config) error {
+	if inBattle(param) {
+		return nil
+	}
 	
 	location := param.Location
-
-	fmt.Printf("Exploring %v...\n",location)
-
-	pokeUrl := fmt.Sprintf("https://pokeapi.co/api/v2/location-area/%v", location)
-	
-	resp, err := http.Get(pokeUrl)
-	
-	if err != nil {
-		fmt.Println("Error calling the PokeURl")
-		return err
+	if len(param.Args) > 0 {
+		location = resolveName(param, strings.Join(param.Args, " "), "location-area")
 	}
 
-	defer resp.Body.Close()
+	fmt.Printf("Exploring %v...\n", localLabel(param, "location-area", location))
 
-	body, err := io.ReadAll(resp.Body)
+	rawOutput, err := fetchLocationArea(param, location)
 
 	if err != nil {
-		fmt.Println("Error reading the response body")
+		reportUnknown(param, location, "area", "location-area")
 		return err
 	}
 
-	var rawOutput PokeLocation
-
-	if err := json.Unmarshal(body, &rawOutput); err != nil {
-		fmt.Println("Error unmarshaling the data")
-		return err
+	if !param.Sandbox && param.Position != "" && rawOutput.Location.Name != param.Position {
+		fmt.Printf("%v is in %v, travel there first\n", location, localLabel(param, "location", rawOutput.Location.Name))
+		return nil
 	}
+
+	param.CurrentArea = &rawOutput
+	param.Wild = nil
+	markAreaSeen(param, rawOutput, param.Version)
 	
 	fmt.Println("Found Pokemon:")
 
-	for _,check := range rawOutput.PokemonEncounters {
-		fmt.Printf("- %v \n",check.Pokemon.Name)
+	for _,name := range availableSpecies(rawOutput, param.Version) {
+		fmt.Printf("- %v \n", localLabel(param, "pokemon-species", name))
 	}
 
 	return nil
@@ -558,103 +655,262 @@ func cleanInput(text string) []string {
 	return conversion
 }
 
-func catchProbability(experienceLevel int) bool {
+// parseArgs splits command arguments into positional words and --flags.
+// A flag takes the following word as its value unless it is one of boolFlags.
+func parseArgs(args []string, boolFlags ...string) ([]string, map[string]string) {
+	positional := []string{}
+	flags := make(map[string]string)
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "--") {
+			positional = append(positional, arg)
+			continue
+		}
 
-	chance := rand.Intn(100)
-	difficulty := experienceLevel / 5
+		name := strings.TrimPrefix(arg, "--")
+		if key, value, found := strings.Cut(name, "="); found {
+			flags[key] = value
+			continue
+		}
 
-	if difficulty >= 90 {
-		difficulty = 90
-	}
+		isBool := false
+		for _, b := range boolFlags {
+			if b == name {
+				isBool = true
+			}
+		}
 
-	return chance > difficulty
+		if isBool || i+1 >= len(args) || strings.HasPrefix(args[i+1], "--") {
+			flags[name] = "true"
+		} else {
+			flags[name] = args[i+1]
+			i++
+		}
+	}
+	return positional, flags
 }
 
 func commandCatch(param *config) error {
-	
+
+	positional, flags := parseArgs(param.Args, "cheat")
+	if nickname, ok := flags["nickname"]; ok {
+		if err := checkNickname(param, nil, nickname); err != nil {
+			fmt.Println(err)
+			return nil
+		}
+	}
+	level := defaultCatchLevel
+	if len(positional) > 0 {
+		param.Encounter = resolveName(param, strings.Join(positional, " "), "pokemon-species")
+	} else if param.Wild != nil {
+		param.Encounter = param.Wild.Name
+	} else {
+		fmt.Println("No Pokemon encountered")
+		return nil
+	}
 	pokemonName := strings.ToLower(param.Encounter)
 
-	pokeUrl := fmt.Sprintf("https://pokeapi.co/api/v2/pokemon/%v", pokemonName)
-	
-	fmt.Printf("Throwing a Pokeball at %v...\n",pokemonName)
+	// The wild pokemon from the last encounter, when that is what we're throwing at
+	var wild *WildPokemon
+	if param.Wild != nil && param.Wild.Name == pokemonName {
+		wild = param.Wild
+		level = wild.Level
+	}
 
-	resp, err := http.Get(pokeUrl)
-	
-	if err != nil {
-		fmt.Println("Error calling GET req on pokeAPI")
-		return err
+	if !param.Sandbox && flags["cheat"] != "true" {
+		if param.CurrentArea == nil {
+			fmt.Println("You aren't anywhere yet! Use explore or travel to pick an area first")
+			return nil
+		}
+
+		available := availableSpecies(*param.CurrentArea, param.Version)
+		if !containsString(available, pokemonName) {
+			fmt.Printf("%v doesn't live in %v.\n", pokemonName, param.CurrentArea.Name)
+			fmt.Printf("Pokemon found here: %v\n", strings.Join(available, ", "))
+			return nil
+		}
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	ballKey := "poke"
+	if value, ok := flags["ball"]; ok {
+		ballKey = normalizeBallKey(value)
+	}
 
+	if _, known := ballMultipliers[ballKey]; !known {
+		fmt.Printf("Unknown ball %q, try one of: %v\n", flags["ball"], strings.Join(ballOrder, ", "))
+		return nil
+	}
+
+	if param.Inventory[ballKey] <= 0 {
+		fmt.Printf("You don't have any %v balls left!\n", ballKey)
+		return nil
+	}
+
+	ball, err := fetchBall(param, ballKey)
 	if err != nil {
-		fmt.Println("Err reading response body")
+		fmt.Println("Error loading the ball from pokeAPI")
 		return err
 	}
 
-	var pokemonData PokeData
+	pokemonData, err := fetchPokemon(param, pokemonName)
 
-	err = json.Unmarshal(body, &pokemonData)
+	if err != nil {
+		reportUnknown(param, pokemonName, "pokemon", "pokemon-species")
+		return err
+	}
 
+	species, err := fetchSpecies(param, pokemonData)
 	if err != nil {
-		fmt.Println("Error finding the pokemon, Make sure it exists")
+		fmt.Println("Error loading the pokemon species")
 		return err
 	}
 
+	if param.Version != "" && !existsInVersion(pokemonData, species, param.Version, param.Generation) {
+		fmt.Printf("%v can't be found in pokemon %v\n", pokemonName, param.Version)
+		return nil
+	}
 
-	if pokemonData.BaseExperience != 0 {
+	fmt.Printf("Throwing a %v at %v...\n", ball.Name, pokemonName)
+	param.Inventory[ballKey]--
 
-		if catchProbability(pokemonData.BaseExperience) {
-			fmt.Printf("%v was caught!\n",pokemonName)
-			
-			if param.Caught == nil {
-                param.Caught = make(map[string]PokeData)
-            }
+	hpFraction := 1.0
+	if wild != nil {
+		hpFraction = wild.hpFraction()
+	}
 
-			param.Caught[strings.ToLower(pokemonData.Name)] = pokemonData
+	result := param.CatchModel.Attempt(CatchTarget{
+		Pokemon: pokemonData,
+		CaptureRate: species.CaptureRate,
+		HPFraction: hpFraction,
+		BallBonus: ball.Multiplier,
+	})
 
-		} else {
-			fmt.Printf("%v escaped!\n",pokemonName)
+	for i := 0; i < result.Shakes && i < 3; i++ {
+		fmt.Println("...the ball shakes...")
+	}
+
+	if result.Caught {
+		fmt.Printf("%v was caught!\n",pokemonName)
+
+		pokemon := newCaughtPokemon(param, pokemonData, level)
+		pokemon.Friendship = species.BaseHappiness
+		if err := rollIndividual(param, pokemon, species); err != nil {
+			fmt.Println("Error loading a nature from pokeAPI")
 		}
+		pokemon.Moves = startingMoves(pokemonData, level, moveVersionGroup(param, pokemonData))
+		if wild != nil {
+			pokemon.Version = wild.Version
+			param.Wild = nil
 
-	}
-	return nil
-}
+			if param.Battle != nil && param.Battle.Wild == wild {
+				param.Battle = nil
+			}
+		}
+		if nickname, ok := flags["nickname"]; ok {
+			pokemon.Nickname = nickname
+		}
+		if pokemon.HeldItem = rollHeldItem(pokemonData, pokemon.Version, param.Roll); pokemon.HeldItem != "" {
+			fmt.Printf("%v was holding %v\n", pokemonName, pokemon.HeldItem)
+		}
 
-func commandPokedex(param *config) error {
-	fmt.Println("Your Pokedex:")
+		// Catching rewards the party lead like winning a battle would
+		if len(param.Party) > 0 {
+			lead := param.Caught[param.Party[0]]
+			if err := gainExperience(param, lead, experienceYield(pokemonData.BaseExperience, level, false), nil); err != nil {
+				fmt.Println("Error loading the growth rate")
+			}
+		}
 
-	if len(param.Caught) == 0 {
-        fmt.Println(" - (no Pokémon caught yet)")
-        return nil
-    }
+		registerCaught(param, pokemon)
+		if place := storeCaught(param, pokemon.ID); place == "party" {
+			fmt.Printf("%v joined your party\n", pokemon.displayName())
+		} else {
+			fmt.Printf("%v was sent to %v\n", pokemon.displayName(), place)
+		}
 
-	for name := range param.Caught {
-		fmt.Printf(" - %s\n", name)
+	} else {
+		fmt.Printf("%v escaped!\n",pokemonName)
 	}
 	return nil
 }
 
 func commandInspect(param *config) error {
-    pokemonName := strings.ToLower(param.Inspect)
+    positional, flags := parseArgs(param.Args, "sprite", "back", "shiny", "ascii")
+    ref := param.Inspect
+    if len(positional) > 0 {
+        ref = strings.Join(positional, " ")
+    }
 
-    pokemonData, exists := param.Caught[pokemonName]
-    if !exists {
-        fmt.Println("Pokémon not found in your caught list.")
+    pokemon, err := findCaught(param, ref)
+    if err != nil {
+        fmt.Println(err)
         return nil
     }
+    if err := pokemon.loadData(param); err != nil {
+        fmt.Println("Error loading the pokemon from pokeAPI")
+        return err
+    }
 
+    pokemonData := pokemon.Data
     saved := savePokemon(pokemonData)
 
-    fmt.Printf("Name: %s\n", saved.Name)
-    fmt.Printf("Height: %d\n", saved.Height)
-    fmt.Printf("Weight: %d\n", saved.Weight)
+    fmt.Printf("Name: %s\n", localDisplayName(param, pokemon))
+    fmt.Printf("National Dex: #%03d\n", dexNumber(pokemon))
+    fmt.Printf("Level: %d\n", pokemon.Level)
+    if growth, err := growthRate(param, pokemon); err == nil && pokemon.Level < maxLevel {
+        fmt.Printf("Experience: %d (%d to next level)\n", pokemon.Experience, growth.experienceForLevel(pokemon.Level+1)-pokemon.Experience)
+    } else {
+        fmt.Printf("Experience: %d\n", pokemon.Experience)
+    }
+    fmt.Printf("Base experience: %d\n", pokemonData.BaseExperience)
+    fmt.Printf("Caught: %s in %s (%s)\n", pokemon.CaughtAt.Format("2006-01-02 15:04"), orUnknown(pokemon.Location), orUnknown(pokemon.Version))
+    fmt.Printf("Gender: %s\n", orUnknown(pokemon.Gender))
+    fmt.Printf("Nature: %s\n", orUnknown(pokemon.Nature.String()))
+    if pokemon.HiddenAbility {
+        fmt.Printf("Ability: %s (hidden)\n", pokemon.Ability)
+    } else {
+        fmt.Printf("Ability: %s\n", orUnknown(pokemon.Ability))
+    }
+    fmt.Printf("Possible abilities: %s\n", orUnknown(abilityNames(pokemonData, " (hidden)")))
+    fmt.Printf("Shiny: %v\n", pokemon.Shiny)
+    fmt.Printf("Sprite: %s\n", spriteURL(pokemon))
+    if len(flags) > 0 {
+        if err := printSprite(param, pokemon, flags); err != nil {
+            return err
+        }
+    }
+    fmt.Printf("Friendship: %d\n", pokemon.Friendship)
+    fmt.Printf("Held item: %s\n", orUnknown(localName(param, "item", pokemon.HeldItem)))
+    fmt.Printf("Height: %s\n", formatHeight(saved.Height))
+    fmt.Printf("Weight: %s\n", formatWeight(saved.Weight))
 	fmt.Println("Stats:")
-    for stat, value := range saved.Stats {
-        fmt.Printf("  - %s: %d\n", stat, value)
+    total := 0
+    for _, stat := range statOrder {
+        total += saved.Stats[stat]
+        fmt.Printf("  %-15s %3d  base %3d %s  IV %2d  EV %3d\n", stat, pokemon.Stats[stat], saved.Stats[stat], statBar(saved.Stats[stat], 15), pokemon.IVs[stat], pokemon.EVs[stat])
+    }
+    fmt.Printf("  %-15s      base %3d\n", "total", total)
+    types := []string{}
+    for _, t := range saved.Types {
+        types = append(types, localName(param, "type", t))
+    }
+    moves := []string{}
+    for _, move := range pokemon.Moves {
+        moves = append(moves, localName(param, "move", move))
     }
-    fmt.Printf("Types: %v\n", saved.Types)
+    fmt.Printf("Types: %v\n", types)
+    fmt.Printf("Moves: %v\n", strings.Join(moves, ", "))
+
+	if param.Version != "" {
+		heldItems := versionHeldItems(pokemonData, param.Version)
+		if len(heldItems) > 0 {
+			fmt.Printf("Held items in %v: %v\n", param.Version, strings.Join(heldItems, ", "))
+		}
+
+		moves := versionMoves(pokemonData, param.VersionGroup)
+		fmt.Printf("Learnable moves in %v (%d): %v\n", param.VersionGroup, len(moves), strings.Join(moves, ", "))
+	}
 
 
     return nil
@@ -706,12 +962,225 @@ func main() {
 			description: "pokedex",
 			callback: commandPokedex,
 		},
+		"catchmodel": {
+			name: "catchmodel",
+			description: "shows or switches the catch formula",
+			callback: commandCatchModel,
+		},
+		"travel": {
+			name: "travel",
+			description: "walks to a location through the routes in between",
+			callback: commandTravel,
+		},
+		"encounter": {
+			name: "encounter",
+			description: "looks for a wild pokemon in the current area",
+			callback: commandEncounter,
+		},
+		"walk": {
+			name: "walk",
+			description: "walks through the grass looking for pokemon",
+			callback: commandWalk,
+		},
+		"fish": {
+			name: "fish",
+			description: "casts a rod for water pokemon",
+			callback: commandFish,
+		},
+		"surf": {
+			name: "surf",
+			description: "surfs looking for water pokemon",
+			callback: commandSurf,
+		},
+		"version": {
+			name: "version",
+			description: "shows or selects the game version",
+			callback: commandVersion,
+		},
+		"sandbox": {
+			name: "sandbox",
+			description: "toggles catching pokemon from anywhere",
+			callback: commandSandbox,
+		},
+		"party": {
+			name: "party",
+			description: "manages your active team",
+			callback: commandParty,
+		},
+		"box": {
+			name: "box",
+			description: "lists the pokemon stored in the PC",
+			callback: commandBox,
+		},
+		"nickname": {
+			name: "nickname",
+			description: "names one of your pokemon",
+			callback: commandNickname,
+		},
+		"battle": {
+			name: "battle",
+			description: "starts a battle against the wild pokemon or one of yours",
+			callback: commandBattle,
+		},
+		"fight": {
+			name: "fight",
+			description: "uses a move in the current battle",
+			callback: commandFight,
+		},
+		"run": {
+			name: "run",
+			description: "flees the current battle",
+			callback: commandRun,
+		},
+		"weakness": {
+			name: "weakness",
+			description: "shows the type matchups against a pokemon",
+			callback: commandWeakness,
+		},
+		"damage": {
+			name: "damage",
+			description: "calculates the damage a move deals",
+			callback: commandDamage,
+		},
+		"evolution": {
+			name: "evolution",
+			description: "shows a pokemon's evolution chain",
+			callback: commandEvolution,
+		},
+		"evolve": {
+			name: "evolve",
+			description: "evolves a caught pokemon",
+			callback: commandEvolve,
+		},
+		"shinyodds": {
+			name: "shinyodds",
+			description: "shows or sets the shiny chance",
+			callback: commandShinyOdds,
+		},
+		"moves": {
+			name: "moves",
+			description: "lists the moves a pokemon can learn",
+			callback: commandMoves,
+		},
+		"learn": {
+			name: "learn",
+			description: "teaches a caught pokemon a move",
+			callback: commandLearn,
+		},
+		"forget": {
+			name: "forget",
+			description: "makes a caught pokemon forget a move",
+			callback: commandForget,
+		},
+		"bag": {
+			name: "bag",
+			description: "shows your Poké Balls and items",
+			callback: commandBag,
+		},
+		"give": {
+			name: "give",
+			description: "gives a caught pokemon an item to hold",
+			callback: commandGive,
+		},
+		"take": {
+			name: "take",
+			description: "takes a held item back into the bag",
+			callback: commandTake,
+		},
+		"use": {
+			name: "use",
+			description: "uses an item from the bag",
+			callback: commandUse,
+		},
+		"dex": {
+			name: "dex",
+			description: "shows the Pokédex entry for a species",
+			callback: commandDex,
+		},
+		"language": {
+			name: "language",
+			description: "shows or sets the language for names",
+			callback: commandLanguage,
+		},
+		"regions": {
+			name: "regions",
+			description: "lists every region",
+			callback: commandRegions,
+		},
+		"region": {
+			name: "region",
+			description: "shows or selects the region",
+			callback: commandRegion,
+		},
+		"locations": {
+			name: "locations",
+			description: "lists the locations in the selected region",
+			callback: commandLocations,
+		},
+		"areas": {
+			name: "areas",
+			description: "lists the areas in a location",
+			callback: commandAreas,
+		},
+		"where": {
+			name: "where",
+			description: "shows where you are",
+			callback: commandWhere,
+		},
+		"route": {
+			name: "route",
+			description: "shows the shortest way between two locations",
+			callback: commandRoute,
+		},
+		"where-to-find": {
+			name: "where-to-find",
+			description: "lists the areas a pokemon lives in",
+			callback: commandWhereToFind,
+		},
+		"nationaldex": {
+			name: "nationaldex",
+			description: "shows national dex completion",
+			callback: commandNationalDex,
+		},
+		"missing": {
+			name: "missing",
+			description: "lists the species you haven't caught yet",
+			callback: commandMissing,
+		},
+		"compare": {
+			name: "compare",
+			description: "compares pokemon side by side",
+			callback: commandCompare,
+		},
+		"save": {
+			name: "save",
+			description: "saves the game",
+			callback: commandSave,
+		},
+		"inventory": {
+			name: "inventory",
+			description: "shows the Poké Balls you are carrying",
+			callback: commandInventory,
+		},
 	}
 
     configPagination := &config{
 		Cache: cache,
 		Location: "",
 		Encounter: "",
+		Inventory: startingInventory(),
+		Bag: startingBag(),
+		Position: startingPosition,
+		CatchModel: catchModels["standard"],
+		SavePath: defaultSavePath(),
+		ShinyOdds: defaultShinyOdds,
+	}
+
+	if err := loadGame(configPagination); err != nil {
+		fmt.Println("Error loading your save, starting a new game:", err)
+	}
+	if err := restorePosition(configPagination); err != nil {
+		fmt.Println("Error loading your position from pokeAPI, use travel to pick one:", err)
 	}
 
 	for {
@@ -726,6 +1195,7 @@ func main() {
 		}
 
 		userCommand := cleanInput[0]
+		configPagination.Args = cleanInput[1:]
 		
 		if command, exists := commands[userCommand]; exists {
 			
@@ -742,10 +1212,8 @@ func main() {
 
 				if len(cleanInput) > 1 {
 					configPagination.Encounter = cleanInput[1]
-					command.callback(configPagination)
-				} else {
-					fmt.Println("No Pokemon encountered")
 				}
+				command.callback(configPagination)
 			} else if command.name == "inspect" {
 
 				if len(cleanInput) > 1 {
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+type PokeMove struct {
+	ID          int              `json:"id"`
+	Name        string           `json:"name"`
+	Power       int              `json:"power"`
+	Accuracy    int              `json:"accuracy"` // 0 for moves that never miss
+	PP          int              `json:"pp"`
+	Priority    int              `json:"priority"`
+	DamageClass NamedAPIResource `json:"damage_class"`
+	Type        NamedAPIResource `json:"type"`
+	Machines    []struct {
+		Machine      NamedAPIResource `json:"machine"`
+		VersionGroup NamedAPIResource `json:"version_group"`
+	} `json:"machines"`
+}
+
+// struggle is used when a pokemon has no damaging move to fall back on
+var struggle = PokeMove{
+	Name:        "struggle",
+	Power:       50,
+	DamageClass: NamedAPIResource{Name: "physical"},
+}
+
+func fetchMove(param *config, name string) (PokeMove, error) {
+	var move PokeMove
+
+	body, err := fetchData(param, fmt.Sprintf("https://pokeapi.co/api/v2/move/%v", name))
+	if err != nil {
+		return move, err
+	}
+
+	if err := json.Unmarshal(body, &move); err != nil {
+		return move, err
+	}
+	return move, nil
+}
+
+type levelUpMove struct {
+	Name  string
+	Level int
+}
+
+// levelUpMoves lists the moves learned by leveling up to level, most recent first.
+// An empty versionGroup accepts level-up data from any game.
+func levelUpMoves(pokemonData PokeData, level int, versionGroup string) []levelUpMove {
+	moves := []levelUpMove{}
+
+	for _, move := range pokemonData.Moves {
+		learnedAt := -1
+		for _, detail := range move.VersionGroupDetails {
+			if detail.MoveLearnMethod.Name != "level-up" || detail.LevelLearnedAt > level {
+				continue
+			}
+			if versionGroup != "" && detail.VersionGroup.Name != versionGroup {
+				continue
+			}
+			if detail.LevelLearnedAt > learnedAt {
+				learnedAt = detail.LevelLearnedAt
+			}
+		}
+
+		if learnedAt >= 0 {
+			moves = append(moves, levelUpMove{Name: move.Move.Name, Level: learnedAt})
+		}
+	}
+
+	sort.SliceStable(moves, func(i, j int) bool { return moves[i].Level > moves[j].Level })
+	return moves
+}
+
+// calcDamage is the standard damage formula: level, attack against defense
+// (or the special stats for special moves), STAB, type effectiveness and a
+// random roll between 85 and 100.
+func calcDamage(attacker, defender *Battler, move PokeMove, chart *TypeChart, roll int) int {
+	if move.Power == 0 {
+		return 0
+	}
+
+	attack, defense := attacker.Stats["attack"], defender.Stats["defense"]
+	if move.DamageClass.Name == "special" {
+		attack, defense = attacker.Stats["special-attack"], defender.Stats["special-defense"]
+	}
+	if defense < 1 {
+		defense = 1
+	}
+
+	base := (2*attacker.Level/5+2)*move.Power*attack/defense/50 + 2
+
+	modifier := float64(roll) / 100
+	if hasSTAB(attacker, move) {
+		modifier *= 1.5
+	}
+
+	effectiveness := chart.effectiveness(move.Type.Name, defender.Types)
+	if effectiveness == 0 {
+		return 0
+	}
+	modifier *= effectiveness
+
+	return int(math.Max(1, math.Floor(float64(base)*modifier)))
+}
+
+// hasSTAB is the same-type attack bonus, a move sharing one of the attacker's types
+func hasSTAB(attacker *Battler, move PokeMove) bool {
+	for _, t := range attacker.Types {
+		if t == move.Type.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func damageRange(attacker, defender *Battler, move PokeMove, chart *TypeChart) (int, int) {
+	return calcDamage(attacker, defender, move, chart, 85), calcDamage(attacker, defender, move, chart, 100)
+}
+
+// damageSide is an attacker or defender for the damage command: a caught
+// pokemon at its own level, or any species at --level.
+func damageSide(param *config, ref string, level int) (*Battler, error) {
+	if pokemon, err := findCaught(param, ref); err == nil {
+		if err := pokemon.loadData(param); err != nil {
+			return nil, err
+		}
+		return battlerFromCaught(pokemon), nil
+	}
+
+	pokemonData, err := fetchPokemon(param, ref)
+	if err != nil {
+		return nil, err
+	}
+	return battlerFromSpecies(pokemonData.Name, pokemonData, level), nil
+}
+
+func commandDamage(param *config) error {
+	positional, flags := parseArgs(param.Args)
+	if len(positional) < 3 {
+		fmt.Println("Usage: damage <attacker> <move> <defender> [--level 50]")
+		return nil
+	}
+
+	level := 50
+	if value, ok := flags["level"]; ok {
+		if _, err := fmt.Sscan(value, &level); err != nil || level < 1 || level > 100 {
+			fmt.Println("The level has to be between 1 and 100")
+			return nil
+		}
+	}
+
+	attacker, err := damageSide(param, positional[0], level)
+	if err != nil {
+		fmt.Printf("Error finding %v, Make sure it exists\n", positional[0])
+		return err
+	}
+
+	defender, err := damageSide(param, positional[2], level)
+	if err != nil {
+		fmt.Printf("Error finding %v, Make sure it exists\n", positional[2])
+		return err
+	}
+
+	move, err := fetchMove(param, positional[1])
+	if err != nil {
+		fmt.Printf("Error finding the move %v\n", positional[1])
+		return err
+	}
+
+	chart, err := loadTypeChart(param)
+	if err != nil {
+		fmt.Println("Error loading the type chart")
+		return err
+	}
+
+	accuracy := "never misses"
+	if move.Accuracy > 0 {
+		accuracy = fmt.Sprintf("%d%% accuracy", move.Accuracy)
+	}
+	fmt.Printf("%v: %v %v, power %d, %v, %d PP\n", move.Name, move.Type.Name, move.DamageClass.Name, move.Power, accuracy, move.PP)
+
+	if move.Power == 0 {
+		fmt.Printf("%v doesn't deal damage directly\n", move.Name)
+		return nil
+	}
+
+	fmt.Printf("%v Lv%d -> %v Lv%d\n", attacker.Name, attacker.Level, defender.Name, defender.Level)
+
+	notes := []string{fmt.Sprintf("%gx effectiveness", chart.effectiveness(move.Type.Name, defender.Types))}
+	if hasSTAB(attacker, move) {
+		notes = append(notes, "STAB")
+	}
+	fmt.Printf("Modifiers: %v\n", strings.Join(notes, ", "))
+
+	low, high := damageRange(attacker, defender, move, chart)
+	fmt.Printf("Damage: %d-%d (%.1f%%-%.1f%% of %d HP)\n", low, high,
+		100*float64(low)/float64(defender.MaxHP), 100*float64(high)/float64(defender.MaxHP), defender.MaxHP)
+	return nil
+}
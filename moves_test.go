@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestDamageRange(t *testing.T) {
+	chart := testTypeChart()
+
+	pikachu := &Battler{Level: 50, Types: []string{"electric"}, Stats: map[string]int{"attack": 75, "special-attack": 70}}
+	gyarados := &Battler{Level: 50, Types: []string{"water", "flying"}, Stats: map[string]int{"defense": 99, "special-defense": 120}}
+	quagsire := &Battler{Level: 50, Types: []string{"water", "ground"}, Stats: map[string]int{"defense": 105, "special-defense": 85}}
+
+	move := func(power int, class, moveType string) PokeMove {
+		return PokeMove{Power: power, DamageClass: NamedAPIResource{Name: class}, Type: NamedAPIResource{Name: moveType}}
+	}
+
+	cases := []struct {
+		attacker *Battler
+		defender *Battler
+		move     PokeMove
+		low      int
+		high     int
+	}{
+		// 22*90*70/120/50 + 2 = 25, then STAB 1.5 and 4x effectiveness
+		{attacker: pikachu, defender: gyarados, move: move(90, "special", "electric"), low: 127, high: 150},
+		// 22*40*75/99/50 + 2 = 15, neutral and no STAB
+		{attacker: pikachu, defender: gyarados, move: move(40, "physical", "normal"), low: 12, high: 15},
+		{attacker: pikachu, defender: quagsire, move: move(90, "special", "electric"), low: 0, high: 0},
+		{attacker: pikachu, defender: quagsire, move: move(0, "status", "electric"), low: 0, high: 0},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			low, high := damageRange(c.attacker, c.defender, c.move, chart)
+			if low != c.low || high != c.high {
+				t.Errorf("expected %v-%v but got %v-%v", c.low, c.high, low, high)
+			}
+		})
+	}
+}
+
+func TestCheckLearn(t *testing.T) {
+	var pokemonData PokeData
+	fixture := `{"moves": [
+		{"move": {"name": "thunder-shock"}, "version_group_details": [
+			{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue"}}
+		]},
+		{"move": {"name": "thunderbolt"}, "version_group_details": [
+			{"level_learned_at": 26, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue"}},
+			{"level_learned_at": 0, "move_learn_method": {"name": "machine"}, "version_group": {"name": "gold-silver"}}
+		]},
+		{"move": {"name": "volt-tackle"}, "version_group_details": [
+			{"level_learned_at": 0, "move_learn_method": {"name": "egg"}, "version_group": {"name": "red-blue"}}
+		]}
+	]}`
+	if err := json.Unmarshal([]byte(fixture), &pokemonData); err != nil {
+		t.Fatalf("could not decode fixture: %v", err)
+	}
+
+	cases := []struct {
+		name         string
+		level        int
+		known        []string
+		move         string
+		versionGroup string
+		canLearn     bool
+		method       string
+	}{
+		{name: "level reached", level: 26, move: "thunderbolt", versionGroup: "red-blue", canLearn: true, method: "level-up"},
+		{name: "level too low", level: 20, move: "thunderbolt", versionGroup: "red-blue", canLearn: false},
+		{name: "machine ignores level", level: 5, move: "thunderbolt", versionGroup: "gold-silver", canLearn: true, method: "machine"},
+		{name: "egg move", level: 50, move: "volt-tackle", versionGroup: "red-blue", canLearn: false},
+		{name: "not in version group", level: 50, move: "thunder-shock", versionGroup: "gold-silver", canLearn: false},
+		{name: "already known", level: 50, known: []string{"thunder-shock"}, move: "thunder-shock", versionGroup: "red-blue", canLearn: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			pokemon := &CaughtPokemon{Species: "pikachu", Level: c.level, Moves: c.known, Data: pokemonData}
+			method, err := checkLearn(pokemon, c.move, c.versionGroup)
+			if (err == nil) != c.canLearn {
+				t.Errorf("expected canLearn=%v but got %v", c.canLearn, err)
+			}
+			if method != c.method {
+				t.Errorf("expected to learn it by %q but got %q", c.method, method)
+			}
+		})
+	}
+
+	if moves := startingMoves(pokemonData, 30, "red-blue"); len(moves) != 2 || moves[0] != "thunderbolt" {
+		t.Errorf("expected thunderbolt then thunder-shock but got %v", moves)
+	}
+}
+
+func TestLearnNeedsMachine(t *testing.T) {
+	var pokemonData PokeData
+	fixture := `{"name": "pikachu", "moves": [{"move": {"name": "thunderbolt"}, "version_group_details": [
+		{"level_learned_at": 0, "move_learn_method": {"name": "machine"}, "version_group": {"name": "gold-silver"}}
+	]}]}`
+	if err := json.Unmarshal([]byte(fixture), &pokemonData); err != nil {
+		t.Fatalf("could not decode fixture: %v", err)
+	}
+
+	param := &config{
+		VersionGroup: "gold-silver",
+		Bag:          map[string]int{},
+		Cache: seedCache(map[string]string{
+			"https://pokeapi.co/api/v2/move/thunderbolt": `{"name": "thunderbolt", "machines": [
+				{"machine": {"url": "https://pokeapi.co/api/v2/machine/5/"}, "version_group": {"name": "red-blue"}},
+				{"machine": {"url": "https://pokeapi.co/api/v2/machine/24/"}, "version_group": {"name": "gold-silver"}}
+			]}`,
+			"https://pokeapi.co/api/v2/machine/24/": `{"id": 24, "item": {"name": "tm24"}}`,
+		}),
+	}
+	pokemon := newCaughtPokemon(param, pokemonData, 5)
+	param.Args = []string{"pikachu", "thunderbolt"}
+
+	if err := commandLearn(param); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(pokemon.Moves) != 0 {
+		t.Errorf("expected thunderbolt not to be learned without tm24, got %v", pokemon.Moves)
+	}
+
+	param.Bag["tm24"] = 1
+	if err := commandLearn(param); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(pokemon.Moves) != 1 || param.Bag["tm24"] != 1 {
+		t.Errorf("expected thunderbolt learned and tm24 kept, got %v and %v", pokemon.Moves, param.Bag)
+	}
+}
@@ -0,0 +1,344 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PokePokedex is a regional or national dex, listing species in dex order
+type PokePokedex struct {
+	ID             int              `json:"id"`
+	Name           string           `json:"name"`
+	Region         NamedAPIResource `json:"region"`
+	PokemonEntries []struct {
+		EntryNumber    int              `json:"entry_number"`
+		PokemonSpecies NamedAPIResource `json:"pokemon_species"`
+	} `json:"pokemon_entries"`
+}
+
+// missing lists this many species per page unless --per-page says otherwise
+const missingPageSize = 50
+
+// nationalDexSize is the number of species in the National Dex
+func nationalDexSize() int {
+	return generationDexEnds[len(generationDexEnds)-1]
+}
+
+// markSeen records a national dex number as seen. Alternate forms have IDs
+// past the National Dex and are skipped.
+func markSeen(param *config, number int) {
+	if number < 1 || number > nationalDexSize() {
+		return
+	}
+	if param.Seen == nil {
+		param.Seen = make(map[int]bool)
+	}
+	param.Seen[number] = true
+}
+
+// registerCaught records a caught pokemon's species, so it stays registered
+// after the pokemon evolves
+func registerCaught(param *config, pokemon *CaughtPokemon) {
+	number := dexNumber(pokemon)
+	if number < 1 || number > nationalDexSize() {
+		return
+	}
+	markSeen(param, number)
+	if param.CaughtDex == nil {
+		param.CaughtDex = make(map[int]bool)
+	}
+	param.CaughtDex[number] = true
+}
+
+// markAreaSeen records every pokemon explore shows for an area
+func markAreaSeen(param *config, area PokeLocation, version string) {
+	for _, encounter := range area.PokemonEncounters {
+		for _, versionDetail := range encounter.VersionDetails {
+			if version == "" || versionDetail.Version.Name == version {
+				markSeen(param, idFromURL(encounter.Pokemon.URL))
+				break
+			}
+		}
+	}
+}
+
+// caughtDex is every species registered as caught, including the ones owned
+// now, which older saves didn't register
+func caughtDex(param *config) map[int]bool {
+	caught := map[int]bool{}
+	for number := range param.CaughtDex {
+		caught[number] = true
+	}
+	for _, pokemon := range param.Caught {
+		caught[dexNumber(pokemon)] = true
+	}
+	return caught
+}
+
+// seenDex is every species seen, and caught ones count as seen
+func seenDex(param *config, caught map[int]bool) map[int]bool {
+	seen := map[int]bool{}
+	for number := range param.Seen {
+		seen[number] = true
+	}
+	for number := range caught {
+		seen[number] = true
+	}
+	return seen
+}
+
+// countRange is how many dex numbers from first to last are in the set
+func countRange(set map[int]bool, first, last int) int {
+	n := 0
+	for number := range set {
+		if number >= first && number <= last {
+			n++
+		}
+	}
+	return n
+}
+
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(n) / float64(total)
+}
+
+func fetchPokedex(param *config, name string) (PokePokedex, error) {
+	var pokedex PokePokedex
+
+	body, err := fetchData(param, fmt.Sprintf("https://pokeapi.co/api/v2/pokedex/%v", name))
+	if err != nil {
+		return pokedex, err
+	}
+
+	if err := json.Unmarshal(body, &pokedex); err != nil {
+		return pokedex, err
+	}
+	return pokedex, nil
+}
+
+// pokedexSpecies is the national dex numbers listed in a regional dex
+func pokedexSpecies(pokedex PokePokedex) []int {
+	numbers := []int{}
+	for _, entry := range pokedex.PokemonEntries {
+		numbers = append(numbers, idFromURL(entry.PokemonSpecies.URL))
+	}
+	return numbers
+}
+
+func commandNationalDex(param *config) error {
+	if err := loadCaughtData(param); err != nil {
+		fmt.Println("Error loading your pokemon from pokeAPI")
+		return err
+	}
+	caught := caughtDex(param)
+	seen := seenDex(param, caught)
+	total := nationalDexSize()
+
+	fmt.Println("National Dex:")
+	fmt.Printf("  Seen %d/%d (%.1f%%), caught %d/%d (%.1f%%)\n", len(seen), total, percent(len(seen), total), len(caught), total, percent(len(caught), total))
+
+	fmt.Println("By generation:")
+	first := 1
+	for i, last := range generationDexEnds {
+		size := last - first + 1
+		n := countRange(caught, first, last)
+		fmt.Printf("  %d: seen %d, caught %d/%d (%.1f%%)\n", i+1, countRange(seen, first, last), n, size, percent(n, size))
+		first = last + 1
+	}
+
+	dexes, err := loadRegionDexes(param)
+	if err != nil {
+		fmt.Println("Error loading the regional pokedexes from pokeAPI")
+		return err
+	}
+
+	fmt.Println("By region:")
+	for _, dex := range dexes {
+		n := 0
+		for _, number := range dex.Species {
+			if caught[number] {
+				n++
+			}
+		}
+		fmt.Printf("  %v: caught %d/%d (%.1f%%)\n", localLabel(param, "region", dex.Region), n, len(dex.Species), percent(n, len(dex.Species)))
+	}
+	return nil
+}
+
+// regionDex is the national dex numbers in a region's original dex
+type regionDex struct {
+	Region  string
+	Species []int
+}
+
+// loadRegionDexes fetches the original dex of every region. That's a couple of
+// requests per region, so it only happens the first time it's needed.
+func loadRegionDexes(param *config) ([]regionDex, error) {
+	if param.RegionDexes != nil {
+		return param.RegionDexes, nil
+	}
+
+	var regions namedResourceList
+	body, err := fetchData(param, "https://pokeapi.co/api/v2/region/?limit=100")
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, &regions); err != nil {
+		return nil, err
+	}
+
+	dexes := []regionDex{}
+	for _, resource := range regions.Results {
+		region, err := fetchRegion(param, resource.Name)
+		if err != nil {
+			return nil, err
+		}
+		if len(region.Pokedexes) == 0 {
+			continue
+		}
+
+		// The first dex of a region is the one from its original games
+		pokedex, err := fetchPokedex(param, region.Pokedexes[0].Name)
+		if err != nil {
+			return nil, err
+		}
+		dexes = append(dexes, regionDex{Region: region.Name, Species: pokedexSpecies(pokedex)})
+	}
+
+	param.RegionDexes = dexes
+	return dexes, nil
+}
+
+// versionSpecies is every national dex number in the dexes of a version group
+func versionSpecies(param *config, versionGroup string) (map[int]bool, error) {
+	var group PokeVersionGroup
+
+	body, err := fetchData(param, fmt.Sprintf("https://pokeapi.co/api/v2/version-group/%v", versionGroup))
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, &group); err != nil {
+		return nil, err
+	}
+
+	species := map[int]bool{}
+	for _, resource := range group.Pokedexes {
+		pokedex, err := fetchPokedex(param, resource.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, number := range pokedexSpecies(pokedex) {
+			species[number] = true
+		}
+	}
+	return species, nil
+}
+
+func commandMissing(param *config) error {
+	_, flags := parseArgs(param.Args, "in-version", "seen")
+
+	perPage := missingPageSize
+	if value, ok := flags["per-page"]; ok {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			fmt.Println("--per-page needs a number of at least 1")
+			return nil
+		}
+		perPage = n
+	}
+	page := 1
+	if value, ok := flags["page"]; ok {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			fmt.Println("--page needs a number")
+			return nil
+		}
+		page = n
+	}
+
+	first, last := 1, nationalDexSize()
+	if value, ok := flags["gen"]; ok {
+		f, err := parsePokedexFilter(map[string]string{"gen": value})
+		if err != nil {
+			fmt.Println(err)
+			return nil
+		}
+		last = generationDexEnds[f.Generation-1]
+		if f.Generation > 1 {
+			first = generationDexEnds[f.Generation-2] + 1
+		}
+	}
+
+	var available map[int]bool
+	if flags["in-version"] == "true" {
+		if param.VersionGroup == "" {
+			fmt.Println("Select a game first with version <game>")
+			return nil
+		}
+		species, err := versionSpecies(param, param.VersionGroup)
+		if err != nil {
+			fmt.Println("Error loading the version's pokedex from pokeAPI")
+			return err
+		}
+		available = species
+	}
+
+	var list namedResourceList
+	body, err := fetchData(param, fmt.Sprintf("https://pokeapi.co/api/v2/pokemon-species/?limit=%d", nationalDexSize()))
+	if err != nil {
+		fmt.Println("Error loading the species list from pokeAPI")
+		return err
+	}
+	if err := json.Unmarshal(body, &list); err != nil {
+		return err
+	}
+
+	if err := loadCaughtData(param); err != nil {
+		fmt.Println("Error loading your pokemon from pokeAPI")
+		return err
+	}
+	caught := caughtDex(param)
+	seen := seenDex(param, caught)
+
+	missing := []string{}
+	for _, species := range list.Results {
+		number := idFromURL(species.URL)
+		switch {
+		case number < first || number > last || caught[number]:
+			continue
+		case available != nil && !available[number]:
+			continue
+		case flags["seen"] == "true" && !seen[number]:
+			continue
+		}
+
+		name := species.Name
+		if seen[number] {
+			name += " (seen)"
+		}
+		missing = append(missing, fmt.Sprintf("#%03d %v", number, name))
+	}
+
+	if len(missing) == 0 {
+		fmt.Println("Nothing missing, well done!")
+		return nil
+	}
+
+	start, end, ok := pageBounds(len(missing), page, perPage)
+	if !ok {
+		fmt.Printf("There are only %d pages\n", (len(missing)+perPage-1)/perPage)
+		return nil
+	}
+
+	fmt.Println(strings.Join(missing[start:end], "\n"))
+	fmt.Printf("Showing %d-%d of %d species missing\n", start+1, end, len(missing))
+	if end < len(missing) {
+		fmt.Printf("Use --page %d for more\n", page+1)
+	}
+	return nil
+}
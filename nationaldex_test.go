@@ -0,0 +1,75 @@
+package main
+
+import "testing"
+
+func TestDexCompletion(t *testing.T) {
+	param := &config{Caught: map[int]*CaughtPokemon{}}
+	collection := testCollection(t)
+
+	// charmander was caught and then evolved away, arcanine is still owned
+	registerCaught(param, collection[0])
+	param.Caught[4] = collection[3]
+	markSeen(param, 25)
+	markSeen(param, 10033) // a mega form, not a species
+	markSeen(param, 0)
+
+	caught := caughtDex(param)
+	seen := seenDex(param, caught)
+
+	if len(caught) != 2 || !caught[4] || !caught[59] {
+		t.Errorf("expected charmander and arcanine caught, got %v", caught)
+	}
+	if len(seen) != 3 || !seen[25] {
+		t.Errorf("expected pikachu and the caught species seen, got %v", seen)
+	}
+	if len(param.Seen) != 2 {
+		t.Errorf("expected only real dex numbers recorded, got %v", param.Seen)
+	}
+
+	cases := []struct {
+		first, last int
+		expected    int
+	}{
+		{first: 1, last: 151, expected: 3},
+		{first: 1, last: 25, expected: 2},
+		{first: 152, last: 251, expected: 0},
+	}
+	for _, c := range cases {
+		if actual := countRange(seen, c.first, c.last); actual != c.expected {
+			t.Errorf("For #%d-#%d, expected %d seen but got %d", c.first, c.last, c.expected, actual)
+		}
+	}
+
+	if actual := percent(1, 4); actual != 25 {
+		t.Errorf("expected 25%% but got %v", actual)
+	}
+	if actual := percent(1, 0); actual != 0 {
+		t.Errorf("expected 0%% of nothing but got %v", actual)
+	}
+}
+
+func TestLoadRegionDexesOnce(t *testing.T) {
+	param := &config{Cache: seedCache(map[string]string{
+		"https://pokeapi.co/api/v2/region/?limit=100": `{"results": [{"name": "kanto"}, {"name": "hisui"}]}`,
+		"https://pokeapi.co/api/v2/region/kanto":      `{"name": "kanto", "pokedexes": [{"name": "kanto"}, {"name": "letsgo-kanto"}]}`,
+		"https://pokeapi.co/api/v2/region/hisui":      `{"name": "hisui", "pokedexes": []}`,
+		"https://pokeapi.co/api/v2/pokedex/kanto": `{"name": "kanto", "pokemon_entries": [
+			{"entry_number": 1, "pokemon_species": {"url": "https://pokeapi.co/api/v2/pokemon-species/1/"}},
+			{"entry_number": 2, "pokemon_species": {"url": "https://pokeapi.co/api/v2/pokemon-species/2/"}}
+		]}`,
+	})}
+
+	dexes, err := loadRegionDexes(param)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(dexes) != 1 || dexes[0].Region != "kanto" || len(dexes[0].Species) != 2 {
+		t.Errorf("expected only kanto's dex of two species, got %v", dexes)
+	}
+
+	// The second time nothing is fetched
+	param.Cache = seedCache(nil)
+	if again, err := loadRegionDexes(param); err != nil || len(again) != 1 {
+		t.Errorf("expected the dexes kept from the first load, got %v (%v)", again, err)
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"fmt"
+)
+
+const partySize = 6
+const boxSize = 30
+
+// PCBox holds caught pokemon that aren't travelling in the party
+type PCBox struct {
+	Name    string `json:"name"`
+	Pokemon []int  `json:"pokemon"`
+}
+
+func indexOf(list []int, id int) int {
+	for i, item := range list {
+		if item == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func removeAt(list []int, i int) []int {
+	return append(list[:i:i], list[i+1:]...)
+}
+
+// storeCaught puts a freshly caught pokemon in the party, or the PC once the party is full
+func storeCaught(param *config, id int) string {
+	if len(param.Party) < partySize {
+		param.Party = append(param.Party, id)
+		return "party"
+	}
+	return depositInBox(param, id)
+}
+
+func depositInBox(param *config, id int) string {
+	for i := range param.Boxes {
+		if len(param.Boxes[i].Pokemon) < boxSize {
+			param.Boxes[i].Pokemon = append(param.Boxes[i].Pokemon, id)
+			return param.Boxes[i].Name
+		}
+	}
+
+	box := PCBox{
+		Name:    fmt.Sprintf("Box %d", len(param.Boxes)+1),
+		Pokemon: []int{id},
+	}
+	param.Boxes = append(param.Boxes, box)
+	return box.Name
+}
+
+func findInBoxes(param *config, id int) (int, int) {
+	for b, box := range param.Boxes {
+		if i := indexOf(box.Pokemon, id); i >= 0 {
+			return b, i
+		}
+	}
+	return -1, -1
+}
+
+func withdrawFromBox(param *config, id int) bool {
+	b, i := findInBoxes(param, id)
+	if b < 0 {
+		return false
+	}
+	param.Boxes[b].Pokemon = removeAt(param.Boxes[b].Pokemon, i)
+	return true
+}
+
+func printParty(param *config) {
+	fmt.Println("Your party:")
+	if len(param.Party) == 0 {
+		fmt.Println(" - (empty)")
+		return
+	}
+
+	for i, id := range param.Party {
+		pokemon := param.Caught[id]
+		if i == 0 {
+			fmt.Printf(" %d. %v Lv%d (lead)\n", i+1, pokemon.displayName(), pokemon.Level)
+		} else {
+			fmt.Printf(" %d. %v Lv%d\n", i+1, pokemon.displayName(), pokemon.Level)
+		}
+	}
+}
+
+func commandParty(param *config) error {
+	if len(param.Args) == 0 {
+		printParty(param)
+		return nil
+	}
+
+	action := param.Args[0]
+	refs := []*CaughtPokemon{}
+	for _, ref := range param.Args[1:] {
+		pokemon, err := findCaught(param, ref)
+		if err != nil {
+			fmt.Println(err)
+			return nil
+		}
+		refs = append(refs, pokemon)
+	}
+
+	switch action {
+	case "add":
+		if len(refs) == 0 {
+			fmt.Println("Which pokemon should join the party?")
+			return nil
+		}
+		pokemon := refs[0]
+		if indexOf(param.Party, pokemon.ID) >= 0 {
+			fmt.Printf("%v is already in your party\n", pokemon.displayName())
+			return nil
+		}
+		if len(param.Party) >= partySize {
+			fmt.Printf("Your party is full, remove a pokemon first (max %d)\n", partySize)
+			return nil
+		}
+		if !withdrawFromBox(param, pokemon.ID) {
+			fmt.Printf("%v isn't in your PC\n", pokemon.displayName())
+			return nil
+		}
+		param.Party = append(param.Party, pokemon.ID)
+		fmt.Printf("%v joined your party\n", pokemon.displayName())
+
+	case "remove":
+		if len(refs) == 0 {
+			fmt.Println("Which pokemon should leave the party?")
+			return nil
+		}
+		pokemon := refs[0]
+		i := indexOf(param.Party, pokemon.ID)
+		if i < 0 {
+			fmt.Printf("%v isn't in your party\n", pokemon.displayName())
+			return nil
+		}
+		if len(param.Party) == 1 {
+			fmt.Printf("%v is the last pokemon in your party and can't leave it\n", pokemon.displayName())
+			return nil
+		}
+		param.Party = removeAt(param.Party, i)
+		fmt.Printf("%v was sent to %v\n", pokemon.displayName(), depositInBox(param, pokemon.ID))
+
+	case "swap":
+		if len(refs) < 2 {
+			fmt.Println("Usage: party swap <a> <b>")
+			return nil
+		}
+		swapParty(param, refs[0], refs[1])
+
+	case "lead":
+		if len(refs) == 0 {
+			fmt.Println("Which pokemon should lead the party?")
+			return nil
+		}
+		pokemon := refs[0]
+		i := indexOf(param.Party, pokemon.ID)
+		if i < 0 {
+			fmt.Printf("%v isn't in your party\n", pokemon.displayName())
+			return nil
+		}
+		param.Party = append([]int{pokemon.ID}, removeAt(param.Party, i)...)
+		fmt.Printf("%v now leads your party\n", pokemon.displayName())
+
+	default:
+		fmt.Println("Usage: party [add|remove|lead <pokemon>] [swap <a> <b>]")
+	}
+	return nil
+}
+
+// swapParty swaps two party members, or trades a party member for one in the PC
+func swapParty(param *config, a, b *CaughtPokemon) {
+	i, j := indexOf(param.Party, a.ID), indexOf(param.Party, b.ID)
+
+	if i < 0 && j >= 0 {
+		a, b = b, a
+		i, j = j, i
+	}
+
+	switch {
+	case i < 0:
+		fmt.Printf("Neither %v nor %v is in your party\n", a.displayName(), b.displayName())
+	case j >= 0:
+		param.Party[i], param.Party[j] = param.Party[j], param.Party[i]
+		fmt.Printf("Swapped %v and %v\n", a.displayName(), b.displayName())
+	default:
+		box, slot := findInBoxes(param, b.ID)
+		if box < 0 {
+			fmt.Printf("%v isn't in your party or PC\n", b.displayName())
+			return
+		}
+		param.Boxes[box].Pokemon[slot] = a.ID
+		param.Party[i] = b.ID
+		fmt.Printf("%v joined your party, %v went to %v\n", b.displayName(), a.displayName(), param.Boxes[box].Name)
+	}
+}
+
+func commandBox(param *config) error {
+	if len(param.Boxes) == 0 {
+		fmt.Println("Your PC is empty")
+		return nil
+	}
+
+	for _, box := range param.Boxes {
+		fmt.Printf("%v (%d/%d):\n", box.Name, len(box.Pokemon), boxSize)
+		for _, id := range box.Pokemon {
+			pokemon := param.Caught[id]
+			fmt.Printf(" - %v Lv%d\n", pokemon.displayName(), pokemon.Level)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func partyFixture(party []int, boxes ...[]int) *config {
+	param := &config{
+		Caught: make(map[int]*CaughtPokemon),
+		Party:  append([]int{}, party...),
+	}
+	for i, box := range boxes {
+		param.Boxes = append(param.Boxes, PCBox{
+			Name:    fmt.Sprintf("Box %d", i+1),
+			Pokemon: append([]int{}, box...),
+		})
+	}
+	for id := 1; id <= 10; id++ {
+		param.Caught[id] = &CaughtPokemon{ID: id, Species: fmt.Sprintf("species-%d", id), Level: 5}
+	}
+	return param
+}
+
+func TestCommandParty(t *testing.T) {
+	cases := []struct {
+		name          string
+		party         []int
+		box           []int
+		args          string
+		expectedParty []int
+		expectedBox   []int
+	}{
+		{
+			name:          "add from the PC",
+			party:         []int{1, 2},
+			box:           []int{3, 4},
+			args:          "add 4",
+			expectedParty: []int{1, 2, 4},
+			expectedBox:   []int{3},
+		},
+		{
+			name:          "add to a full party",
+			party:         []int{1, 2, 3, 4, 5, 6},
+			box:           []int{7},
+			args:          "add 7",
+			expectedParty: []int{1, 2, 3, 4, 5, 6},
+			expectedBox:   []int{7},
+		},
+		{
+			name:          "add a party member",
+			party:         []int{1, 2},
+			box:           []int{3},
+			args:          "add 2",
+			expectedParty: []int{1, 2},
+			expectedBox:   []int{3},
+		},
+		{
+			name:          "remove to the PC",
+			party:         []int{1, 2, 3},
+			box:           []int{4},
+			args:          "remove 2",
+			expectedParty: []int{1, 3},
+			expectedBox:   []int{4, 2},
+		},
+		{
+			name:          "remove the last party member",
+			party:         []int{1},
+			box:           []int{4},
+			args:          "remove 1",
+			expectedParty: []int{1},
+			expectedBox:   []int{4},
+		},
+		{
+			name:          "remove a pokemon in the PC",
+			party:         []int{1, 2},
+			box:           []int{4},
+			args:          "remove 4",
+			expectedParty: []int{1, 2},
+			expectedBox:   []int{4},
+		},
+		{
+			name:          "lead",
+			party:         []int{1, 2, 3},
+			box:           []int{},
+			args:          "lead 3",
+			expectedParty: []int{3, 1, 2},
+			expectedBox:   []int{},
+		},
+		{
+			name:          "lead with a pokemon in the PC",
+			party:         []int{1, 2},
+			box:           []int{3},
+			args:          "lead 3",
+			expectedParty: []int{1, 2},
+			expectedBox:   []int{3},
+		},
+		{
+			name:          "swap party with party",
+			party:         []int{1, 2, 3},
+			box:           []int{4},
+			args:          "swap 1 3",
+			expectedParty: []int{3, 2, 1},
+			expectedBox:   []int{4},
+		},
+		{
+			name:          "swap party with PC",
+			party:         []int{1, 2, 3},
+			box:           []int{4, 5},
+			args:          "swap 2 5",
+			expectedParty: []int{1, 5, 3},
+			expectedBox:   []int{4, 2},
+		},
+		{
+			name:          "swap PC with party",
+			party:         []int{1, 2, 3},
+			box:           []int{4, 5},
+			args:          "swap 4 1",
+			expectedParty: []int{4, 2, 3},
+			expectedBox:   []int{1, 5},
+		},
+		{
+			name:          "swap into a full party",
+			party:         []int{1, 2, 3, 4, 5, 6},
+			box:           []int{7},
+			args:          "swap 7 6",
+			expectedParty: []int{1, 2, 3, 4, 5, 7},
+			expectedBox:   []int{6},
+		},
+		{
+			name:          "swap two pokemon in the PC",
+			party:         []int{1},
+			box:           []int{4, 5},
+			args:          "swap 4 5",
+			expectedParty: []int{1},
+			expectedBox:   []int{4, 5},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			param := partyFixture(c.party, c.box)
+			param.Args = strings.Fields(c.args)
+
+			if err := commandParty(param); err != nil {
+				t.Fatalf("party %v failed: %v", c.args, err)
+			}
+			if !reflect.DeepEqual(param.Party, c.expectedParty) {
+				t.Errorf("expected party %v but got %v", c.expectedParty, param.Party)
+			}
+			if !reflect.DeepEqual(param.Boxes[0].Pokemon, c.expectedBox) {
+				t.Errorf("expected box %v but got %v", c.expectedBox, param.Boxes[0].Pokemon)
+			}
+		})
+	}
+}
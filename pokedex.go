@@ -0,0 +1,254 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// pokedex shows this many pokemon per page unless --per-page says otherwise
+const pokedexPageSize = 20
+
+// generationDexEnds is the last national dex number introduced by each generation
+var generationDexEnds = []int{151, 251, 386, 493, 649, 721, 809, 905, 1025}
+
+// dexNumber is the national dex number of a caught pokemon's species
+func dexNumber(pokemon *CaughtPokemon) int {
+	if id := idFromURL(pokemon.Data.Species.URL); id > 0 {
+		return id
+	}
+	return pokemon.Data.ID
+}
+
+// dexGeneration is the generation a national dex number was introduced in, 0 if unknown
+func dexGeneration(number int) int {
+	for i, end := range generationDexEnds {
+		if number >= 1 && number <= end {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func baseStatTotal(pokemonData PokeData) int {
+	total := 0
+	for _, base := range baseStats(pokemonData) {
+		total += base
+	}
+	return total
+}
+
+// pokedexSorts are the keys accepted by pokedex --sort, besides name and each base stat
+var pokedexSorts = map[string]func(a, b *CaughtPokemon) bool{
+	"id":     func(a, b *CaughtPokemon) bool { return a.ID < b.ID },
+	"dex":    func(a, b *CaughtPokemon) bool { return dexNumber(a) < dexNumber(b) },
+	"caught": func(a, b *CaughtPokemon) bool { return a.CaughtAt.Before(b.CaughtAt) },
+	"level":  func(a, b *CaughtPokemon) bool { return a.Level < b.Level },
+	"total":  func(a, b *CaughtPokemon) bool { return baseStatTotal(a.Data) < baseStatTotal(b.Data) },
+}
+
+// pokedexSort orders by a key from pokedexSorts, a base stat, or by name
+// using the names the list shows. Ties keep ID order.
+func pokedexSort(key string, shown map[int]string) (func(a, b *CaughtPokemon) bool, bool) {
+	if key == "name" {
+		return func(a, b *CaughtPokemon) bool {
+			return strings.ToLower(shown[a.ID]) < strings.ToLower(shown[b.ID])
+		}, true
+	}
+	if less, ok := pokedexSorts[key]; ok {
+		return less, true
+	}
+	if containsString(statOrder, key) {
+		return func(a, b *CaughtPokemon) bool {
+			return baseStats(a.Data)[key] < baseStats(b.Data)[key]
+		}, true
+	}
+	return nil, false
+}
+
+func sortCaught(list []*CaughtPokemon, less func(a, b *CaughtPokemon) bool, desc bool) {
+	sort.SliceStable(list, func(i, j int) bool {
+		if desc {
+			return less(list[j], list[i])
+		}
+		return less(list[i], list[j])
+	})
+}
+
+// pokedexFilter keeps the pokemon matching every filter that is set.
+// Min and Max are inclusive base stat bounds, "total" is the stat total.
+type pokedexFilter struct {
+	Types      []string
+	Min        map[string]int
+	Max        map[string]int
+	Generation int
+	Shiny      bool
+}
+
+func (f pokedexFilter) matches(pokemon *CaughtPokemon) bool {
+	saved := savePokemon(pokemon.Data)
+
+	for _, t := range f.Types {
+		if !containsString(saved.Types, t) {
+			return false
+		}
+	}
+	if f.Shiny && !pokemon.Shiny {
+		return false
+	}
+	if f.Generation > 0 && dexGeneration(dexNumber(pokemon)) != f.Generation {
+		return false
+	}
+
+	value := func(stat string) int {
+		if stat == "total" {
+			return baseStatTotal(pokemon.Data)
+		}
+		return saved.Stats[stat]
+	}
+	for stat, bound := range f.Min {
+		if value(stat) < bound {
+			return false
+		}
+	}
+	for stat, bound := range f.Max {
+		if value(stat) > bound {
+			return false
+		}
+	}
+	return true
+}
+
+func filterCaught(list []*CaughtPokemon, f pokedexFilter) []*CaughtPokemon {
+	matching := []*CaughtPokemon{}
+	for _, pokemon := range list {
+		if f.matches(pokemon) {
+			matching = append(matching, pokemon)
+		}
+	}
+	return matching
+}
+
+// pokedexOptions are the pokedex flags that sort and page rather than filter
+var pokedexOptions = []string{"sort", "desc", "page", "per-page"}
+
+// parsePokedexFilter reads --type, --gen, --shiny and --min-<stat>/--max-<stat>,
+// and rejects any flag that isn't one of those or pokedexOptions
+func parsePokedexFilter(flags map[string]string) (pokedexFilter, error) {
+	f := pokedexFilter{Min: map[string]int{}, Max: map[string]int{}}
+
+	for name, value := range flags {
+		switch {
+		case name == "type":
+			f.Types = strings.Split(value, ",")
+		case name == "shiny":
+			f.Shiny = true
+		case name == "gen":
+			gen, err := strconv.Atoi(value)
+			if err != nil {
+				gen = generationNumber(value)
+			}
+			if gen < 1 || gen > len(generationDexEnds) {
+				return f, fmt.Errorf("there's no generation %v", value)
+			}
+			f.Generation = gen
+		case strings.HasPrefix(name, "min-") || strings.HasPrefix(name, "max-"):
+			stat := name[len("min-"):]
+			if stat != "total" && !containsString(statOrder, stat) {
+				return f, fmt.Errorf("unknown stat %v, use total or one of %v", stat, strings.Join(statOrder, ", "))
+			}
+			bound, err := strconv.Atoi(value)
+			if err != nil {
+				return f, fmt.Errorf("--%v needs a number", name)
+			}
+			if strings.HasPrefix(name, "min-") {
+				f.Min[stat] = bound
+			} else {
+				f.Max[stat] = bound
+			}
+		case !containsString(pokedexOptions, name):
+			return f, fmt.Errorf("unknown option --%v", name)
+		}
+	}
+	return f, nil
+}
+
+func commandPokedex(param *config) error {
+	_, flags := parseArgs(param.Args, "desc", "shiny")
+
+	if len(param.Caught) == 0 {
+		fmt.Println("Your Pokedex:")
+		fmt.Println(" - (no Pokémon caught yet)")
+		return nil
+	}
+
+	filter, err := parsePokedexFilter(flags)
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+
+	sortBy := "id"
+	if s, ok := flags["sort"]; ok {
+		sortBy = s
+	}
+	perPage := pokedexPageSize
+	if value, ok := flags["per-page"]; ok {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			fmt.Println("--per-page needs a number of at least 1")
+			return nil
+		}
+		perPage = n
+	}
+	page := 1
+	if value, ok := flags["page"]; ok {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			fmt.Println("--page needs a number")
+			return nil
+		}
+		page = n
+	}
+
+	if err := loadCaughtData(param); err != nil {
+		fmt.Println("Error loading your pokemon from pokeAPI")
+		return err
+	}
+	list := filterCaught(sortedCaught(param), filter)
+
+	shown := map[int]string{}
+	if sortBy == "name" {
+		for _, pokemon := range list {
+			shown[pokemon.ID] = localDisplayName(param, pokemon)
+		}
+	}
+	less, ok := pokedexSort(sortBy, shown)
+	if !ok {
+		fmt.Printf("Unknown sort %v, use id, name, dex, caught, level, total or a stat\n", sortBy)
+		return nil
+	}
+	sortCaught(list, less, flags["desc"] == "true")
+
+	fmt.Println("Your Pokedex:")
+	if len(list) == 0 {
+		fmt.Printf(" - (none of your %d pokemon match)\n", len(param.Caught))
+		return nil
+	}
+
+	start, end, ok := pageBounds(len(list), page, perPage)
+	if !ok {
+		fmt.Printf("There are only %d pages\n", (len(list)+perPage-1)/perPage)
+		return nil
+	}
+
+	for _, pokemon := range list[start:end] {
+		fmt.Printf(" - %s Lv%d\n", localDisplayName(param, pokemon), pokemon.Level)
+	}
+	fmt.Printf("Showing %d-%d of %d matching (%d caught)\n", start+1, end, len(list), len(param.Caught))
+	if end < len(list) {
+		fmt.Printf("Use --page %d for more\n", page+1)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testCaught builds a caught pokemon from a species fixture
+func testCaught(t *testing.T, id int, species string, shiny bool, fixture string) *CaughtPokemon {
+	t.Helper()
+
+	var pokemonData PokeData
+	if err := json.Unmarshal([]byte(fixture), &pokemonData); err != nil {
+		t.Fatalf("could not decode fixture for %v: %v", species, err)
+	}
+	pokemonData.Name = species
+	return &CaughtPokemon{
+		ID:       id,
+		Species:  species,
+		Shiny:    shiny,
+		Level:    id * 10,
+		CaughtAt: time.Date(2024, 1, 10-id, 0, 0, 0, 0, time.UTC),
+		Data:     pokemonData,
+	}
+}
+
+func testCollection(t *testing.T) []*CaughtPokemon {
+	return []*CaughtPokemon{
+		testCaught(t, 1, "charmander", false, `{"species": {"url": "https://pokeapi.co/api/v2/pokemon-species/4/"},
+			"types": [{"type": {"name": "fire"}}],
+			"stats": [{"base_stat": 39, "stat": {"name": "hp"}}, {"base_stat": 65, "stat": {"name": "speed"}}]}`),
+		testCaught(t, 2, "cyndaquil", true, `{"species": {"url": "https://pokeapi.co/api/v2/pokemon-species/155/"},
+			"types": [{"type": {"name": "fire"}}],
+			"stats": [{"base_stat": 39, "stat": {"name": "hp"}}, {"base_stat": 65, "stat": {"name": "speed"}}]}`),
+		testCaught(t, 3, "bulbasaur", false, `{"species": {"url": "https://pokeapi.co/api/v2/pokemon-species/1/"},
+			"types": [{"type": {"name": "grass"}}, {"type": {"name": "poison"}}],
+			"stats": [{"base_stat": 45, "stat": {"name": "hp"}}, {"base_stat": 45, "stat": {"name": "speed"}}]}`),
+		testCaught(t, 4, "arcanine", false, `{"species": {"url": "https://pokeapi.co/api/v2/pokemon-species/59/"},
+			"types": [{"type": {"name": "fire"}}],
+			"stats": [{"base_stat": 90, "stat": {"name": "hp"}}, {"base_stat": 95, "stat": {"name": "speed"}}]}`),
+	}
+}
+
+func caughtIDs(list []*CaughtPokemon) []int {
+	ids := []int{}
+	for _, pokemon := range list {
+		ids = append(ids, pokemon.ID)
+	}
+	return ids
+}
+
+func TestPokedexFilter(t *testing.T) {
+	cases := []struct {
+		flags    map[string]string
+		expected []int
+	}{
+		{flags: map[string]string{}, expected: []int{1, 2, 3, 4}},
+		{flags: map[string]string{"type": "fire"}, expected: []int{1, 2, 4}},
+		{flags: map[string]string{"type": "grass,poison"}, expected: []int{3}},
+		{flags: map[string]string{"shiny": "true"}, expected: []int{2}},
+		{flags: map[string]string{"gen": "2"}, expected: []int{2}},
+		{flags: map[string]string{"gen": "generation-i"}, expected: []int{1, 3, 4}},
+		{flags: map[string]string{"min-speed": "65"}, expected: []int{1, 2, 4}},
+		{flags: map[string]string{"max-total": "104", "type": "fire"}, expected: []int{1, 2}},
+	}
+
+	for _, c := range cases {
+		f, err := parsePokedexFilter(c.flags)
+		if err != nil {
+			t.Errorf("For %v, unexpected error %v", c.flags, err)
+			continue
+		}
+		if actual := caughtIDs(filterCaught(testCollection(t), f)); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("For %v, expected %v but got %v", c.flags, c.expected, actual)
+		}
+	}
+
+	for _, bad := range []map[string]string{{"gen": "10"}, {"min-luck": "5"}, {"max-hp": "lots"}, {"tpye": "fire"}} {
+		if _, err := parsePokedexFilter(bad); err == nil {
+			t.Errorf("For %v, expected an error", bad)
+		}
+	}
+}
+
+func TestPokedexSort(t *testing.T) {
+	cases := []struct {
+		key      string
+		desc     bool
+		nickname string
+		expected []int
+	}{
+		{key: "id", expected: []int{1, 2, 3, 4}},
+		{key: "name", expected: []int{4, 3, 1, 2}},
+		{key: "name", nickname: "blaze", expected: []int{4, 1, 3, 2}},
+		{key: "dex", expected: []int{3, 1, 4, 2}},
+		{key: "caught", expected: []int{4, 3, 2, 1}},
+		{key: "speed", desc: true, expected: []int{4, 1, 2, 3}},
+		{key: "total", expected: []int{3, 1, 2, 4}},
+	}
+
+	for _, c := range cases {
+		list := testCollection(t)
+		list[0].Nickname = c.nickname
+		shown := map[int]string{}
+		for _, pokemon := range list {
+			shown[pokemon.ID] = pokemon.displayName()
+		}
+
+		less, ok := pokedexSort(c.key, shown)
+		if !ok {
+			t.Errorf("expected %v to be a sort key", c.key)
+			continue
+		}
+		sortCaught(list, less, c.desc)
+		if actual := caughtIDs(list); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("Sorting by %v, expected %v but got %v", c.key, c.expected, actual)
+		}
+	}
+
+	if _, ok := pokedexSort("luck", nil); ok {
+		t.Errorf("expected luck not to be a sort key")
+	}
+}
+
+func TestDexGeneration(t *testing.T) {
+	cases := map[int]int{1: 1, 151: 1, 152: 2, 493: 4, 1025: 9, 0: 0, 2000: 0}
+	for number, expected := range cases {
+		if actual := dexGeneration(number); actual != expected {
+			t.Errorf("For #%d, expected generation %d but got %d", number, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultCatchLevel = 5
+
+// CaughtPokemon is one individual pokemon owned by the trainer
+type CaughtPokemon struct {
+	ID       int            `json:"id"`
+	Nickname string         `json:"nickname,omitempty"`
+	Species  string         `json:"species"`
+	CaughtAt time.Time      `json:"caught_at"`
+	Location string         `json:"location"`
+	Version  string         `json:"version"`
+	Level    int            `json:"level"`
+	Stats    map[string]int `json:"stats"`
+	Data     PokeData       `json:"-"` // Loaded from PokeAPI by loadData, saves only keep Species
+
+	Experience int            `json:"experience"`
+	GrowthRate string         `json:"growth_rate"`
+	IVs        map[string]int `json:"ivs"`
+	EVs        map[string]int `json:"evs"`
+	Friendship int            `json:"friendship"`
+
+	Nature        Nature `json:"nature"`
+	Ability       string `json:"ability"`
+	AbilitySlot   int    `json:"ability_slot"`
+	HiddenAbility bool   `json:"hidden_ability"`
+	Gender        string `json:"gender"`
+	Shiny         bool   `json:"shiny"`
+
+	Moves    []string `json:"moves"` // Up to four active moves
+	HeldItem string   `json:"held_item,omitempty"`
+}
+
+// loadData fetches the pokemon's PokeAPI data the first time it's needed
+func (p *CaughtPokemon) loadData(param *config) error {
+	if p.Data.Name != "" {
+		return nil
+	}
+
+	pokemonData, err := fetchPokemon(param, p.Species)
+	if err != nil {
+		return err
+	}
+	p.Data = pokemonData
+	return nil
+}
+
+// loadCaughtData loads the PokeAPI data of every caught pokemon
+func loadCaughtData(param *config) error {
+	for _, pokemon := range sortedCaught(param) {
+		if err := pokemon.loadData(param); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recalcStats refreshes Stats after the level, IVs or EVs change
+func (p *CaughtPokemon) recalcStats() {
+	p.Stats = computeStats(p.Data, p.Level, p.IVs, p.EVs, p.Nature)
+}
+
+// displayName is how a caught pokemon shows up in lists, e.g. "sparky (pikachu #3)"
+func (p *CaughtPokemon) displayName() string {
+	name := fmt.Sprintf("%v #%d", p.Species, p.ID)
+	if p.Nickname != "" {
+		name = fmt.Sprintf("%v (%v #%d)", p.Nickname, p.Species, p.ID)
+	}
+	if p.Shiny {
+		name += " ✨"
+	}
+	return name
+}
+
+func newCaughtPokemon(param *config, pokemonData PokeData, level int) *CaughtPokemon {
+	param.NextID++
+
+	pokemon := &CaughtPokemon{
+		ID:       param.NextID,
+		Species:  pokemonData.Name,
+		CaughtAt: time.Now(),
+		Version:  param.Version,
+		Level:    level,
+		Data:     pokemonData,
+		IVs:      make(map[string]int),
+		EVs:      make(map[string]int),
+	}
+	pokemon.recalcStats()
+
+	if param.CurrentArea != nil {
+		pokemon.Location = param.CurrentArea.Name
+	}
+
+	if param.Caught == nil {
+		param.Caught = make(map[int]*CaughtPokemon)
+	}
+	param.Caught[pokemon.ID] = pokemon
+	return pokemon
+}
+
+// sortedCaught returns every caught pokemon ordered by ID
+func sortedCaught(param *config) []*CaughtPokemon {
+	list := []*CaughtPokemon{}
+	for _, pokemon := range param.Caught {
+		list = append(list, pokemon)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// findCaught resolves what the user typed into one caught pokemon. It accepts
+// an ID ("3" or "#3"), a nickname, or a species name when only one is owned.
+func findCaught(param *config, ref string) (*CaughtPokemon, error) {
+	ref = strings.ToLower(ref)
+
+	if id, err := strconv.Atoi(strings.TrimPrefix(ref, "#")); err == nil {
+		if pokemon, ok := param.Caught[id]; ok {
+			return pokemon, nil
+		}
+		return nil, fmt.Errorf("no caught pokemon has ID #%d", id)
+	}
+
+	bySpecies := func(species string) []*CaughtPokemon {
+		matches := []*CaughtPokemon{}
+		for _, pokemon := range sortedCaught(param) {
+			if pokemon.Species == species {
+				matches = append(matches, pokemon)
+			}
+		}
+		return matches
+	}
+
+	for _, pokemon := range sortedCaught(param) {
+		if pokemon.Nickname == ref {
+			return pokemon, nil
+		}
+	}
+	matches := bySpecies(ref)
+	if len(matches) == 0 {
+		matches = bySpecies(resolveName(param, ref, "pokemon-species"))
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%v isn't in your caught list", ref)
+	case 1:
+		return matches[0], nil
+	}
+
+	names := []string{}
+	for _, pokemon := range matches {
+		names = append(names, pokemon.displayName())
+	}
+	return nil, fmt.Errorf("you have %d %v, pick one by ID or nickname: %v", len(matches), ref, strings.Join(names, ", "))
+}
+
+// checkNickname rejects nicknames findCaught couldn't tell apart: numbers,
+// which look like IDs, and names another pokemon already has. pokemon is
+// nil for one that hasn't been caught yet.
+func checkNickname(param *config, pokemon *CaughtPokemon, nickname string) error {
+	if _, err := strconv.Atoi(strings.TrimPrefix(nickname, "#")); err == nil {
+		return errors.New("nicknames can't be numbers")
+	}
+	for _, other := range param.Caught {
+		if other != pokemon && other.Nickname == nickname {
+			return fmt.Errorf("%v is already called %v", other.displayName(), nickname)
+		}
+	}
+	return nil
+}
+
+func commandNickname(param *config) error {
+	if len(param.Args) == 0 {
+		fmt.Println("Usage: nickname <pokemon> [name]")
+		return nil
+	}
+
+	pokemon, err := findCaught(param, param.Args[0])
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+
+	if len(param.Args) < 2 {
+		pokemon.Nickname = ""
+		fmt.Printf("Cleared the nickname of %v\n", pokemon.displayName())
+		return nil
+	}
+
+	nickname := param.Args[1]
+	if err := checkNickname(param, pokemon, nickname); err != nil {
+		fmt.Println(err)
+		return nil
+	}
+
+	pokemon.Nickname = nickname
+	fmt.Printf("%v is now called %v\n", pokemon.displayName(), nickname)
+	return nil
+}
+
+// formatHeight turns PokeAPI decimetres into metres and feet and inches
+func formatHeight(decimetres int) string {
+	inches := int(math.Round(float64(decimetres) * 3.93701))
+	return fmt.Sprintf("%.1f m (%d'%02d\")", float64(decimetres)/10, inches/12, inches%12)
+}
+
+// formatWeight turns PokeAPI hectograms into kilograms and pounds
+func formatWeight(hectograms int) string {
+	return fmt.Sprintf("%.1f kg (%.1f lbs)", float64(hectograms)/10, float64(hectograms)*0.220462)
+}
+
+func orUnknown(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+func TestFormatUnits(t *testing.T) {
+	heights := []struct {
+		decimetres int
+		expected   string
+	}{
+		{decimetres: 4, expected: "0.4 m (1'04\")"},
+		{decimetres: 17, expected: "1.7 m (5'07\")"},
+		{decimetres: 145, expected: "14.5 m (47'07\")"},
+	}
+	for _, c := range heights {
+		if actual := formatHeight(c.decimetres); actual != c.expected {
+			t.Errorf("For %d dm, expected %q but got %q", c.decimetres, c.expected, actual)
+		}
+	}
+
+	weights := []struct {
+		hectograms int
+		expected   string
+	}{
+		{hectograms: 60, expected: "6.0 kg (13.2 lbs)"},
+		{hectograms: 905, expected: "90.5 kg (199.5 lbs)"},
+	}
+	for _, c := range weights {
+		if actual := formatWeight(c.hectograms); actual != c.expected {
+			t.Errorf("For %d hg, expected %q but got %q", c.hectograms, c.expected, actual)
+		}
+	}
+}
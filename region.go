@@ -0,0 +1,200 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// map shows this many of the selected region's locations at a time
+const regionPageSize = 20
+
+type PokeRegion struct {
+	ID             int                `json:"id"`
+	Name           string             `json:"name"`
+	Locations      []NamedAPIResource `json:"locations"`
+	MainGeneration NamedAPIResource   `json:"main_generation"`
+	VersionGroups  []NamedAPIResource `json:"version_groups"`
+	Pokedexes      []NamedAPIResource `json:"pokedexes"`
+	Names          []LocalizedName    `json:"names"`
+}
+
+// PokeLocationInfo is a /location, the town or route an area belongs to
+type PokeLocationInfo struct {
+	ID     int                `json:"id"`
+	Name   string             `json:"name"`
+	Region NamedAPIResource   `json:"region"`
+	Areas  []NamedAPIResource `json:"areas"`
+	Names  []LocalizedName    `json:"names"`
+}
+
+type namedResourceList struct {
+	Count   int                `json:"count"`
+	Results []NamedAPIResource `json:"results"`
+}
+
+func fetchRegion(param *config, name string) (PokeRegion, error) {
+	var region PokeRegion
+
+	body, err := fetchData(param, fmt.Sprintf("https://pokeapi.co/api/v2/region/%v", name))
+	if err != nil {
+		return region, err
+	}
+
+	if err := json.Unmarshal(body, &region); err != nil {
+		return region, err
+	}
+	return region, nil
+}
+
+func fetchLocation(param *config, name string) (PokeLocationInfo, error) {
+	var location PokeLocationInfo
+
+	body, err := fetchData(param, fmt.Sprintf("https://pokeapi.co/api/v2/location/%v", name))
+	if err != nil {
+		return location, err
+	}
+
+	if err := json.Unmarshal(body, &location); err != nil {
+		return location, err
+	}
+	return location, nil
+}
+
+// pageBounds is the slice of n items shown on a page counted from 1, or
+// ok=false past either end
+func pageBounds(n, page, size int) (int, int, bool) {
+	start := (page - 1) * size
+	if page < 1 || start >= n {
+		return 0, 0, false
+	}
+	return start, min(start+size, n), true
+}
+
+// mapRegion moves the region's map by step pages and lists the areas of
+// each location on that page
+func mapRegion(param *config, step int) error {
+	region, err := fetchRegion(param, param.Region)
+	if err != nil {
+		fmt.Println("Error loading the region from pokeAPI")
+		return err
+	}
+
+	page := param.RegionPage + step
+	start, end, ok := pageBounds(len(region.Locations), page, regionPageSize)
+	if !ok {
+		if step < 0 {
+			fmt.Printf("You're on the first page of %v\n", region.Name)
+		} else {
+			fmt.Printf("You're on the last page of %v\n", region.Name)
+		}
+		return nil
+	}
+	param.RegionPage = page
+
+	for _, resource := range region.Locations[start:end] {
+		location, err := fetchLocation(param, resource.Name)
+		if err != nil {
+			fmt.Println("Error loading the location from pokeAPI")
+			return err
+		}
+		for _, area := range location.Areas {
+			fmt.Println(localLabel(param, "location-area", area.Name))
+		}
+	}
+	fmt.Printf("(%v locations %d-%d of %d)\n", region.Name, start+1, end, len(region.Locations))
+	return nil
+}
+
+func commandRegions(param *config) error {
+	var regions namedResourceList
+
+	body, err := fetchData(param, "https://pokeapi.co/api/v2/region/?limit=100")
+	if err != nil {
+		fmt.Println("Error loading the regions from pokeAPI")
+		return err
+	}
+	if err := json.Unmarshal(body, &regions); err != nil {
+		return err
+	}
+
+	fmt.Println("Regions:")
+	for _, region := range regions.Results {
+		marker := ""
+		if region.Name == param.Region {
+			marker = " (selected)"
+		}
+		fmt.Printf(" - %v%v\n", localLabel(param, "region", region.Name), marker)
+	}
+	return nil
+}
+
+func commandRegion(param *config) error {
+	if len(param.Args) == 0 {
+		if param.Region == "" {
+			fmt.Println("No region selected, map shows every area")
+		} else {
+			fmt.Printf("Current region: %v\n", localLabel(param, "region", param.Region))
+		}
+		return nil
+	}
+
+	if param.Args[0] == "any" || param.Args[0] == "all" {
+		param.Region = ""
+		param.RegionPage = 0
+		fmt.Println("map shows every area again")
+		return nil
+	}
+
+	region, err := fetchRegion(param, resolveName(param, strings.Join(param.Args, " "), "region"))
+	if err != nil {
+		reportUnknown(param, strings.Join(param.Args, " "), "region", "region")
+		return err
+	}
+
+	param.Region = region.Name
+	param.RegionPage = 0
+	fmt.Printf("Region set to %v (generation %d, %d locations)\n", localLabel(param, "region", region.Name), generationNumber(region.MainGeneration.Name), len(region.Locations))
+	return nil
+}
+
+func commandLocations(param *config) error {
+	if param.Region == "" {
+		fmt.Println("Select a region first with region <name>, see regions for the list")
+		return nil
+	}
+
+	region, err := fetchRegion(param, param.Region)
+	if err != nil {
+		fmt.Println("Error loading the region from pokeAPI")
+		return err
+	}
+
+	fmt.Printf("Locations in %v:\n", localLabel(param, "region", region.Name))
+	for _, location := range region.Locations {
+		fmt.Printf(" - %v\n", localLabel(param, "location", location.Name))
+	}
+	return nil
+}
+
+func commandAreas(param *config) error {
+	if len(param.Args) == 0 {
+		fmt.Println("Usage: areas <location>")
+		return nil
+	}
+
+	location, err := fetchLocation(param, resolveName(param, strings.Join(param.Args, " "), "location"))
+	if err != nil {
+		reportUnknown(param, strings.Join(param.Args, " "), "location", "location")
+		return err
+	}
+
+	fmt.Printf("Areas in %v (%v):\n", localLabel(param, "location", location.Name), orUnknown(location.Region.Name))
+	if len(location.Areas) == 0 {
+		fmt.Println(" - (no areas with wild pokemon)")
+	}
+	for _, area := range location.Areas {
+		fmt.Printf(" - %v\n", localLabel(param, "location-area", area.Name))
+	}
+	return nil
+}
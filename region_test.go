@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+func TestPageBounds(t *testing.T) {
+	cases := []struct {
+		n, page    int
+		start, end int
+		ok         bool
+	}{
+		{n: 45, page: 1, start: 0, end: 20, ok: true},
+		{n: 45, page: 2, start: 20, end: 40, ok: true},
+		{n: 45, page: 3, start: 40, end: 45, ok: true},
+		{n: 45, page: 4, ok: false},
+		{n: 45, page: 0, ok: false},
+		{n: 40, page: 3, ok: false},
+		{n: 0, page: 1, ok: false},
+	}
+
+	for _, c := range cases {
+		start, end, ok := pageBounds(c.n, c.page, 20)
+		if ok != c.ok || (ok && (start != c.start || end != c.end)) {
+			t.Errorf("For page %d of %d, expected %d-%d (%v) but got %d-%d (%v)", c.page, c.n, c.start, c.end, c.ok, start, end, ok)
+		}
+	}
+}
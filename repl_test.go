@@ -38,4 +38,57 @@ func TestCleanInput( t *testing.T) {
 			}
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestParseArgs(t *testing.T) {
+	cases := []struct{
+		input []string
+		boolFlags []string
+		positional []string
+		flags map[string]string
+	}{
+		{
+		input: []string{"pikachu", "--ball", "ultra"},
+		positional: []string{"pikachu"},
+		flags: map[string]string{"ball": "ultra"},
+		},
+		{
+		input: []string{"pikachu", "--ball=great"},
+		positional: []string{"pikachu"},
+		flags: map[string]string{"ball": "great"},
+		},
+		{
+		input: []string{"--cheat", "pikachu"},
+		boolFlags: []string{"cheat"},
+		positional: []string{"pikachu"},
+		flags: map[string]string{"cheat": "true"},
+		},
+		{
+		input: []string{"pikachu", "--desc"},
+		positional: []string{"pikachu"},
+		flags: map[string]string{"desc": "true"},
+		},
+	}
+
+	for _,c := range cases {
+
+		positional, flags := parseArgs(c.input, c.boolFlags...)
+
+		if len(positional) != len(c.positional) {
+			t.Errorf("For input %q, expected positional %q but got %q", c.input, c.positional, positional)
+			continue
+		}
+
+		for i := range positional {
+			if positional[i] != c.positional[i] {
+				t.Errorf("For input %q, expected %q but got %q", c.input, c.positional[i], positional[i])
+			}
+		}
+
+		for name, value := range c.flags {
+			if flags[name] != value {
+				t.Errorf("For input %q, expected --%s=%q but got %q", c.input, name, value, flags[name])
+			}
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// saveFormat is bumped whenever SaveData changes in a way older saves can't be read
+const saveFormat = 2
+
+// SaveData is everything about the trainer that survives a restart
+type SaveData struct {
+	Format       int                    `json:"format"`
+	Caught       map[int]*CaughtPokemon `json:"caught"`
+	NextID       int                    `json:"next_id"`
+	Party        []int                  `json:"party"`
+	Boxes        []PCBox                `json:"boxes"`
+	Inventory    map[string]int         `json:"inventory"`
+	Version      string                 `json:"version"`
+	VersionGroup string                 `json:"version_group"`
+	Generation   int                    `json:"generation"`
+	ShinyOdds    int                    `json:"shiny_odds"`
+	Bag          map[string]int         `json:"bag"`
+	Language     string                 `json:"language,omitempty"`
+	Region       string                 `json:"region,omitempty"`
+	Position     string                 `json:"position,omitempty"`
+	Area         string                 `json:"area,omitempty"`
+	Seen         map[int]bool           `json:"seen,omitempty"`
+	CaughtDex    map[int]bool           `json:"caught_dex,omitempty"`
+	LocalNames   map[string]string      `json:"local_names,omitempty"`
+	IndexedNames map[string]bool        `json:"indexed_names,omitempty"`
+}
+
+// defaultSavePath is $POKEDEX_SAVE, or ~/.pokedexcli/save.json
+func defaultSavePath() string {
+	if path := os.Getenv("POKEDEX_SAVE"); path != "" {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "pokedex_save.json"
+	}
+	return filepath.Join(home, ".pokedexcli", "save.json")
+}
+
+func saveGame(param *config) error {
+	if param.SavePath == "" {
+		return errors.New("saving is off because an unreadable save couldn't be moved aside")
+	}
+
+	area := ""
+	if param.CurrentArea != nil {
+		area = param.CurrentArea.Name
+	}
+
+	data := SaveData{
+		Format:       saveFormat,
+		Caught:       param.Caught,
+		NextID:       param.NextID,
+		Party:        param.Party,
+		Boxes:        param.Boxes,
+		Inventory:    param.Inventory,
+		Version:      param.Version,
+		VersionGroup: param.VersionGroup,
+		Generation:   param.Generation,
+		ShinyOdds:    param.ShinyOdds,
+		Bag:          param.Bag,
+		Language:     param.Language,
+		Region:       param.Region,
+		Position:     param.Position,
+		Area:         area,
+		Seen:         param.Seen,
+		CaughtDex:    param.CaughtDex,
+		LocalNames:   param.LocalNames,
+		IndexedNames: param.IndexedNames,
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(param.SavePath), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(param.SavePath, body, 0o644)
+}
+
+// loadGame restores a previous save, a missing file just means a new trainer
+func loadGame(param *config) error {
+	body, err := os.ReadFile(param.SavePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var data SaveData
+	err = json.Unmarshal(body, &data)
+	switch {
+	case err != nil:
+		return setAsideSave(param, fmt.Errorf("%v can't be read: %w", param.SavePath, err))
+	case data.Format != saveFormat:
+		return setAsideSave(param, fmt.Errorf("%v was written by another pokedex (format %d)", param.SavePath, data.Format))
+	}
+
+	param.Caught = data.Caught
+	param.NextID = data.NextID
+	param.Party = data.Party
+	param.Boxes = data.Boxes
+	if data.Inventory != nil {
+		param.Inventory = data.Inventory
+	}
+	param.Version = data.Version
+	param.VersionGroup = data.VersionGroup
+	param.Generation = data.Generation
+	if data.ShinyOdds > 0 {
+		param.ShinyOdds = data.ShinyOdds
+	}
+	param.Language = data.Language
+	param.Region = data.Region
+	param.Seen = data.Seen
+	param.CaughtDex = data.CaughtDex
+	param.LocalNames = data.LocalNames
+	param.IndexedNames = data.IndexedNames
+	if data.Position != "" {
+		param.Position = data.Position
+	}
+	param.Location = data.Area
+	if data.Bag != nil {
+		param.Bag = data.Bag
+	}
+	return nil
+}
+
+// setAsideSave moves a save this pokedex can't read to <save>.bak, so saving
+// on exit doesn't overwrite the collection in it. When that isn't possible
+// saving is turned off for the session instead.
+func setAsideSave(param *config, cause error) error {
+	backup := param.SavePath + ".bak"
+	if _, err := os.Stat(backup); err == nil {
+		param.SavePath = ""
+		return fmt.Errorf("%w; %v already exists, so saving is off until one of them is moved", cause, backup)
+	}
+
+	if err := os.Rename(param.SavePath, backup); err != nil {
+		param.SavePath = ""
+		return fmt.Errorf("%w; it couldn't be moved aside (%v), so saving is off", cause, err)
+	}
+	return fmt.Errorf("%w; it was moved to %v", cause, backup)
+}
+
+func commandSave(param *config) error {
+	if err := saveGame(param); err != nil {
+		fmt.Println("Error saving the game")
+		return err
+	}
+	fmt.Printf("Game saved to %v\n", param.SavePath)
+	return nil
+}
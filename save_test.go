@@ -0,0 +1,95 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+
+	original := &config{
+		SavePath:  path,
+		Inventory: map[string]int{"poke": 3},
+		Version:   "red",
+	}
+	rememberName(original, "pokemon-species", "Bisasam", "bulbasaur")
+	for i := 0; i < 7; i++ {
+		pokemon := newCaughtPokemon(original, PokeData{Name: "pikachu"}, 5)
+		storeCaught(original, pokemon.ID)
+	}
+	original.Caught[1].Nickname = "sparky"
+
+	if err := saveGame(original); err != nil {
+		t.Fatalf("could not save: %v", err)
+	}
+	if body, _ := os.ReadFile(path); strings.Contains(string(body), `"data"`) {
+		t.Errorf("expected only the species saved, not its PokeAPI data")
+	}
+
+	loaded := &config{SavePath: path}
+	if err := loadGame(loaded); err != nil {
+		t.Fatalf("could not load: %v", err)
+	}
+
+	if len(loaded.Party) != partySize {
+		t.Errorf("expected a party of %v but got %v", partySize, loaded.Party)
+	}
+	if len(loaded.Boxes) != 1 || len(loaded.Boxes[0].Pokemon) != 1 || loaded.Boxes[0].Pokemon[0] != 7 {
+		t.Errorf("expected #7 in the first box but got %v", loaded.Boxes)
+	}
+	if len(loaded.Caught) != 7 || loaded.Caught[1].Nickname != "sparky" || loaded.NextID != 7 {
+		t.Errorf("expected seven pikachu with #1 called sparky, got %v", loaded.Caught)
+	}
+	loaded.Cache = seedCache(map[string]string{
+		"https://pokeapi.co/api/v2/pokemon/pikachu": `{"name": "pikachu", "base_experience": 112}`,
+	})
+	if err := loaded.Caught[1].loadData(loaded); err != nil || loaded.Caught[1].Data.BaseExperience != 112 {
+		t.Errorf("expected pikachu's data loaded from PokeAPI, got %v %v", loaded.Caught[1].Data, err)
+	}
+	if resolveName(loaded, "bisasam", "pokemon-species") != "bulbasaur" {
+		t.Errorf("expected remembered names to survive, got %v", loaded.LocalNames)
+	}
+	if loaded.Inventory["poke"] != 3 || loaded.Version != "red" {
+		t.Errorf("expected inventory and version to survive, got %v %v", loaded.Inventory, loaded.Version)
+	}
+}
+
+func TestLoadMissingSave(t *testing.T) {
+	param := &config{SavePath: filepath.Join(t.TempDir(), "missing.json")}
+	if err := loadGame(param); err != nil {
+		t.Errorf("expected a missing save to be ignored, got %v", err)
+	}
+}
+
+func TestLoadUnknownFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := os.WriteFile(path, []byte(`{"format": 99, "caught": {}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	param := &config{SavePath: path}
+	if err := loadGame(param); err == nil {
+		t.Fatalf("expected an error for an unknown format")
+	}
+	if _, err := os.Stat(path + ".bak"); err != nil {
+		t.Fatalf("expected the old save moved to save.json.bak: %v", err)
+	}
+
+	// A second unreadable save can't be moved aside without losing the first
+	if err := os.WriteFile(path, []byte(`not json`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	param = &config{SavePath: path}
+	if err := loadGame(param); err == nil {
+		t.Fatalf("expected an error for an unreadable save")
+	}
+	if err := saveGame(param); err == nil {
+		t.Errorf("expected saving to be off rather than overwrite %v", path)
+	}
+	if body, _ := os.ReadFile(path); string(body) != "not json" {
+		t.Errorf("expected the unreadable save untouched, got %q", body)
+	}
+}
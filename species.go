@@ -0,0 +1,175 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// defaultLanguage is used for names and text when nothing else is selected
+const defaultLanguage = "en"
+
+type PokeSpecies struct {
+	ID             int              `json:"id"`
+	Name           string           `json:"name"`
+	CaptureRate    int              `json:"capture_rate"`
+	BaseHappiness  int              `json:"base_happiness"`
+	GenderRate     int              `json:"gender_rate"`
+	Generation     NamedAPIResource `json:"generation"`
+	GrowthRate     NamedAPIResource `json:"growth_rate"`
+	EvolutionChain struct {
+		URL string `json:"url"`
+	} `json:"evolution_chain"`
+	Varieties []struct {
+		IsDefault bool             `json:"is_default"`
+		Pokemon   NamedAPIResource `json:"pokemon"`
+	} `json:"varieties"`
+	IsBaby      bool               `json:"is_baby"`
+	IsLegendary bool               `json:"is_legendary"`
+	IsMythical  bool               `json:"is_mythical"`
+	Color       NamedAPIResource   `json:"color"`
+	Shape       NamedAPIResource   `json:"shape"`
+	Habitat     NamedAPIResource   `json:"habitat"` // Empty for pokemon after generation III
+	EggGroups   []NamedAPIResource `json:"egg_groups"`
+	Genera      []struct {
+		Genus    string           `json:"genus"`
+		Language NamedAPIResource `json:"language"`
+	} `json:"genera"`
+	FlavorTextEntries []struct {
+		FlavorText string           `json:"flavor_text"`
+		Language   NamedAPIResource `json:"language"`
+		Version    NamedAPIResource `json:"version"`
+	} `json:"flavor_text_entries"`
+	Names []LocalizedName `json:"names"`
+}
+
+// genus is the "Seed Pokémon" style category in a language
+func (species PokeSpecies) genus(language string) string {
+	for _, g := range species.Genera {
+		if g.Language.Name == language {
+			return g.Genus
+		}
+	}
+	return ""
+}
+
+// flavorText is the Pokédex entry for a version in a language. Without an
+// entry for that version, or with no version selected, it's the newest entry
+// in the language. It also returns the version the text came from.
+func (species PokeSpecies) flavorText(version, language string) (string, string) {
+	text, from := "", ""
+	for _, entry := range species.FlavorTextEntries {
+		if entry.Language.Name != language {
+			continue
+		}
+		text, from = entry.FlavorText, entry.Version.Name
+		if version != "" && entry.Version.Name == version {
+			break
+		}
+	}
+	// Entries from the older games have line and page breaks in them
+	return strings.Join(strings.Fields(text), " "), from
+}
+
+func fetchSpeciesURL(param *config, speciesUrl string) (PokeSpecies, error) {
+	var species PokeSpecies
+
+	body, err := fetchData(param, speciesUrl)
+	if err != nil {
+		return species, err
+	}
+
+	if err := json.Unmarshal(body, &species); err != nil {
+		return species, err
+	}
+	return species, nil
+}
+
+// fetchSpecies loads the /pokemon-species entry a pokemon belongs to
+func fetchSpecies(param *config, pokemonData PokeData) (PokeSpecies, error) {
+	return fetchSpeciesURL(param, pokemonData.Species.URL)
+}
+
+// fetchDefaultVariety loads the /pokemon a species normally appears as,
+// e.g. wormadam-plant for wormadam
+func fetchDefaultVariety(param *config, speciesName string) (PokeData, error) {
+	species, err := fetchSpeciesURL(param, fmt.Sprintf("https://pokeapi.co/api/v2/pokemon-species/%v", speciesName))
+	if err != nil {
+		return PokeData{}, err
+	}
+
+	for _, variety := range species.Varieties {
+		if variety.IsDefault {
+			return fetchPokemon(param, variety.Pokemon.Name)
+		}
+	}
+	return fetchPokemon(param, speciesName)
+}
+
+func commandDex(param *config) error {
+	positional, flags := parseArgs(param.Args)
+	if len(positional) == 0 {
+		fmt.Println("Usage: dex <pokemon> [--version <version>] [--lang <language>]")
+		return nil
+	}
+
+	pokemonData, err := lookupPokemon(param, positional[0])
+	if err != nil {
+		fmt.Println("Error finding the pokemon, Make sure it exists")
+		return err
+	}
+
+	species, err := fetchSpecies(param, pokemonData)
+	if err != nil {
+		fmt.Println("Error loading the species from pokeAPI")
+		return err
+	}
+
+	version := param.Version
+	if v, ok := flags["version"]; ok {
+		version = v
+	}
+	language := textLanguage(param)
+	if l, ok := flags["lang"]; ok {
+		language = l
+	}
+
+	name := species.Name
+	if param.Language != "" {
+		name = localize(species.Names, param.Language, species.Name)
+		rememberName(param, "pokemon-species", name, species.Name)
+	}
+	fmt.Printf("#%03d %s, the %s\n", species.ID, name, orUnknown(species.genus(language)))
+
+	text, from := species.flavorText(version, language)
+	switch {
+	case text == "":
+		fmt.Printf("No Pokédex entry in %q\n", language)
+	case version != "" && from != version:
+		fmt.Printf("(%v has no entry, this is from %v)\n", version, from)
+		fmt.Println(text)
+	default:
+		fmt.Println(text)
+	}
+
+	eggGroups := []string{}
+	for _, group := range species.EggGroups {
+		eggGroups = append(eggGroups, group.Name)
+	}
+
+	fmt.Printf("Generation: %d\n", generationNumber(species.Generation.Name))
+	fmt.Printf("Habitat: %s\n", orUnknown(species.Habitat.Name))
+	fmt.Printf("Color: %s\n", orUnknown(species.Color.Name))
+	fmt.Printf("Shape: %s\n", orUnknown(species.Shape.Name))
+	fmt.Printf("Egg groups: %s\n", orUnknown(strings.Join(eggGroups, ", ")))
+	fmt.Printf("Base happiness: %d\n", species.BaseHappiness)
+	switch {
+	case species.IsLegendary:
+		fmt.Println("Legendary pokemon")
+	case species.IsMythical:
+		fmt.Println("Mythical pokemon")
+	case species.IsBaby:
+		fmt.Println("Baby pokemon")
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSpeciesText(t *testing.T) {
+	var species PokeSpecies
+	fixture := `{
+		"genera": [
+			{"genus": "Seed Pokémon", "language": {"name": "en"}},
+			{"genus": "Pokémon Graine", "language": {"name": "fr"}}
+		],
+		"flavor_text_entries": [
+			{"flavor_text": "A strange seed was\nplanted on its\fback at birth.", "language": {"name": "en"}, "version": {"name": "red"}},
+			{"flavor_text": "Au matin de sa vie, la graine sur son dos lui fournit les éléments dont il a besoin pour grandir.", "language": {"name": "fr"}, "version": {"name": "x"}},
+			{"flavor_text": "It can go for days without eating a single morsel.", "language": {"name": "en"}, "version": {"name": "emerald"}},
+			{"flavor_text": "There is a plant seed on its back right from the day this Pokémon is born.", "language": {"name": "en"}, "version": {"name": "x"}}
+		]
+	}`
+	if err := json.Unmarshal([]byte(fixture), &species); err != nil {
+		t.Fatalf("could not decode fixture: %v", err)
+	}
+
+	if genus := species.genus("fr"); genus != "Pokémon Graine" {
+		t.Errorf("expected the French genus but got %q", genus)
+	}
+	if genus := species.genus("ja"); genus != "" {
+		t.Errorf("expected no genus in Japanese but got %q", genus)
+	}
+
+	cases := []struct {
+		version  string
+		language string
+		expected string
+		from     string
+	}{
+		{version: "red", language: "en", expected: "A strange seed was planted on its back at birth.", from: "red"},
+		{version: "emerald", language: "en", expected: "It can go for days without eating a single morsel.", from: "emerald"},
+		{version: "", language: "en", expected: "There is a plant seed on its back right from the day this Pokémon is born.", from: "x"},
+		{version: "gold", language: "en", expected: "There is a plant seed on its back right from the day this Pokémon is born.", from: "x"},
+		{version: "red", language: "fr", expected: "Au matin de sa vie, la graine sur son dos lui fournit les éléments dont il a besoin pour grandir.", from: "x"},
+		{version: "red", language: "ja", expected: "", from: ""},
+	}
+
+	for _, c := range cases {
+		text, from := species.flavorText(c.version, c.language)
+		if text != c.expected || from != c.from {
+			t.Errorf("For %q in %q, expected %q from %q but got %q from %q", c.version, c.language, c.expected, c.from, text, from)
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Sprites wider than this many columns are scaled down before rendering
+const defaultSpriteWidth = 48
+
+// asciiRamp goes from the faintest to the densest character
+const asciiRamp = ".:-=+*#%@"
+
+// spriteVariant selects one of the sprites PokeAPI has for a pokemon.
+// Generation 0 means the default sprites from the latest games.
+type spriteVariant struct {
+	Back       bool
+	Shiny      bool
+	Female     bool
+	Generation int
+}
+
+// firstURL returns the first URL that isn't empty
+func firstURL(urls ...string) string {
+	for _, url := range urls {
+		if url != "" {
+			return url
+		}
+	}
+	return ""
+}
+
+// pickSprite finds the sprite URL for a variant, or "" when that game
+// doesn't have one. Female sprites fall back to the regular ones.
+func pickSprite(pokemonData PokeData, v spriteVariant) string {
+	sprites := pokemonData.Sprites
+	versions := sprites.Versions
+
+	switch v.Generation {
+	case 0:
+		switch {
+		case v.Back && v.Shiny:
+			return firstURL(femaleURL(v, sprites.BackShinyFemale), sprites.BackShiny)
+		case v.Back:
+			return firstURL(femaleURL(v, sprites.BackFemale), sprites.BackDefault)
+		case v.Shiny:
+			return firstURL(femaleURL(v, sprites.FrontShinyFemale), sprites.FrontShiny)
+		}
+		return firstURL(femaleURL(v, sprites.FrontFemale), sprites.FrontDefault)
+
+	case 1:
+		// Red and Blue had no shiny pokemon
+		if v.Shiny {
+			return ""
+		}
+		rb := versions.GenerationI.RedBlue
+		if v.Back {
+			return firstURL(rb.BackTransparent, rb.BackDefault)
+		}
+		return firstURL(rb.FrontTransparent, rb.FrontDefault)
+
+	case 2:
+		crystal := versions.GenerationIi.Crystal
+		switch {
+		case v.Back && v.Shiny:
+			return firstURL(crystal.BackShinyTransparent, crystal.BackShiny)
+		case v.Back:
+			return firstURL(crystal.BackTransparent, crystal.BackDefault)
+		case v.Shiny:
+			return firstURL(crystal.FrontShinyTransparent, crystal.FrontShiny)
+		}
+		return firstURL(crystal.FrontTransparent, crystal.FrontDefault)
+
+	case 3:
+		frlg := versions.GenerationIii.FireredLeafgreen
+		return frontBack(v, frlg.FrontDefault, frlg.FrontShiny, frlg.BackDefault, frlg.BackShiny)
+
+	case 4:
+		platinum := versions.GenerationIv.Platinum
+		switch {
+		case v.Back && v.Shiny:
+			return platinum.BackShiny
+		case v.Back:
+			return platinum.BackDefault
+		case v.Shiny:
+			return firstURL(femaleURL(v, platinum.FrontShinyFemale), platinum.FrontShiny)
+		}
+		return firstURL(femaleURL(v, platinum.FrontFemale), platinum.FrontDefault)
+
+	case 5:
+		bw := versions.GenerationV.BlackWhite
+		switch {
+		case v.Back && v.Shiny:
+			return firstURL(femaleURL(v, bw.BackShinyFemale), bw.BackShiny)
+		case v.Back:
+			return firstURL(femaleURL(v, bw.BackFemale), bw.BackDefault)
+		case v.Shiny:
+			return firstURL(femaleURL(v, bw.FrontShinyFemale), bw.FrontShiny)
+		}
+		return firstURL(femaleURL(v, bw.FrontFemale), bw.FrontDefault)
+
+	case 6:
+		xy := versions.GenerationVi.XY
+		return frontOnly(v, firstURL(femaleURL(v, xy.FrontFemale), xy.FrontDefault), firstURL(femaleURL(v, xy.FrontShinyFemale), xy.FrontShiny))
+
+	case 7:
+		usum := versions.GenerationVii.UltraSunUltraMoon
+		return frontOnly(v, firstURL(femaleURL(v, usum.FrontFemale), usum.FrontDefault), firstURL(femaleURL(v, usum.FrontShinyFemale), usum.FrontShiny))
+	}
+	return ""
+}
+
+func femaleURL(v spriteVariant, url string) string {
+	if v.Female {
+		return url
+	}
+	return ""
+}
+
+func frontBack(v spriteVariant, front, frontShiny, back, backShiny string) string {
+	switch {
+	case v.Back && v.Shiny:
+		return backShiny
+	case v.Back:
+		return back
+	case v.Shiny:
+		return frontShiny
+	}
+	return front
+}
+
+// frontOnly is for the 3D games, which have no back sprites
+func frontOnly(v spriteVariant, front, frontShiny string) string {
+	return frontBack(v, front, frontShiny, "", "")
+}
+
+// fetchSprite downloads a sprite through the API cache and decodes it
+func fetchSprite(param *config, url string) (image.Image, error) {
+	body, err := fetchData(param, url)
+	if err != nil {
+		return nil, err
+	}
+	return png.Decode(bytes.NewReader(body))
+}
+
+func opaque(c color.Color) bool {
+	_, _, _, a := c.RGBA()
+	return a >= 0x8000
+}
+
+// cropTransparent trims the empty border most sprites have around the pokemon
+func cropTransparent(img image.Image) image.Rectangle {
+	bounds := img.Bounds()
+	crop := image.Rectangle{Min: bounds.Max, Max: bounds.Min}
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if !opaque(img.At(x, y)) {
+				continue
+			}
+			crop.Min.X = min(crop.Min.X, x)
+			crop.Min.Y = min(crop.Min.Y, y)
+			crop.Max.X = max(crop.Max.X, x+1)
+			crop.Max.Y = max(crop.Max.Y, y+1)
+		}
+	}
+
+	if crop.Empty() {
+		return bounds
+	}
+	return crop
+}
+
+// sampler maps a grid of cells onto the cropped sprite, scaling it down to
+// width columns with nearest neighbour sampling
+type sampler struct {
+	img   image.Image
+	crop  image.Rectangle
+	scale float64
+}
+
+func newSampler(img image.Image, width int) sampler {
+	crop := cropTransparent(img)
+	scale := 1.0
+	if width > 0 && crop.Dx() > width {
+		scale = float64(crop.Dx()) / float64(width)
+	}
+	return sampler{img: img, crop: crop, scale: scale}
+}
+
+func (s sampler) columns() int {
+	return int(float64(s.crop.Dx()) / s.scale)
+}
+
+func (s sampler) rows() int {
+	return int(float64(s.crop.Dy()) / s.scale)
+}
+
+// at returns the pixel for column x and row y, and whether it's visible
+func (s sampler) at(x, y int) (color.NRGBA, bool) {
+	if y >= s.rows() {
+		return color.NRGBA{}, false
+	}
+	px := s.crop.Min.X + int(float64(x)*s.scale)
+	py := s.crop.Min.Y + int(float64(y)*s.scale)
+	c := s.img.At(px, py)
+	return color.NRGBAModel.Convert(c).(color.NRGBA), opaque(c)
+}
+
+// renderHalfBlocks draws two pixels per character with "▀", the top one as
+// the foreground and the bottom one as the background, in 24-bit color
+func renderHalfBlocks(img image.Image, width int) string {
+	s := newSampler(img, width)
+	var b strings.Builder
+
+	for y := 0; y < s.rows(); y += 2 {
+		for x := 0; x < s.columns(); x++ {
+			top, topVisible := s.at(x, y)
+			bottom, bottomVisible := s.at(x, y+1)
+
+			switch {
+			case topVisible && bottomVisible:
+				fmt.Fprintf(&b, "\x1b[38;2;%d;%d;%dm\x1b[48;2;%d;%d;%dm▀\x1b[0m", top.R, top.G, top.B, bottom.R, bottom.G, bottom.B)
+			case topVisible:
+				fmt.Fprintf(&b, "\x1b[38;2;%d;%d;%dm▀\x1b[0m", top.R, top.G, top.B)
+			case bottomVisible:
+				fmt.Fprintf(&b, "\x1b[38;2;%d;%d;%dm▄\x1b[0m", bottom.R, bottom.G, bottom.B)
+			default:
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// renderASCII draws the sprite with characters by brightness, for terminals
+// without color. Rows are sampled every other pixel to keep the proportions.
+func renderASCII(img image.Image, width int) string {
+	s := newSampler(img, width)
+	var b strings.Builder
+
+	for y := 0; y < s.rows(); y += 2 {
+		for x := 0; x < s.columns(); x++ {
+			c, visible := s.at(x, y)
+			if !visible {
+				b.WriteByte(' ')
+				continue
+			}
+			// Darker pixels get denser characters
+			luma := (299*int(c.R) + 587*int(c.G) + 114*int(c.B)) / 1000
+			b.WriteByte(asciiRamp[(255-luma)*(len(asciiRamp)-1)/255])
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// supportsColor is false for dumb terminals and when NO_COLOR is set
+func supportsColor() bool {
+	return os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb"
+}
+
+// printSprite renders a caught pokemon's sprite for the inspect flags
+// --back, --shiny, --gen <n>, --ascii and --width <n>
+func printSprite(param *config, pokemon *CaughtPokemon, flags map[string]string) error {
+	variant := spriteVariant{
+		Back:   flags["back"] == "true",
+		Shiny:  pokemon.Shiny || flags["shiny"] == "true",
+		Female: pokemon.Gender == "female",
+	}
+	if gen, ok := flags["gen"]; ok {
+		n, err := strconv.Atoi(gen)
+		if err != nil {
+			n = generationNumber(gen)
+		}
+		if n < 1 || n > 7 {
+			fmt.Println("Sprites are available for generations 1 to 7")
+			return nil
+		}
+		variant.Generation = n
+	}
+
+	width := defaultSpriteWidth
+	if w, err := strconv.Atoi(flags["width"]); err == nil && w > 0 {
+		width = w
+	}
+
+	url := pickSprite(pokemon.Data, variant)
+	if url == "" {
+		fmt.Println("There's no sprite for that variant")
+		return nil
+	}
+
+	img, err := fetchSprite(param, url)
+	if err != nil {
+		fmt.Println("Error loading the sprite")
+		return err
+	}
+
+	if flags["ascii"] == "true" || !supportsColor() {
+		fmt.Print(renderASCII(img, width))
+	} else {
+		fmt.Print(renderHalfBlocks(img, width))
+	}
+	return nil
+}
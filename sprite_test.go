@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestPickSprite(t *testing.T) {
+	var pokemonData PokeData
+	fixture := `{"sprites": {
+		"front_default": "front.png",
+		"front_shiny": "front-shiny.png",
+		"back_default": "back.png",
+		"front_female": "front-female.png",
+		"versions": {
+			"generation-i": {"red-blue": {"front_default": "rb.png", "front_transparent": "rb-transparent.png"}},
+			"generation-iii": {"firered-leafgreen": {"back_shiny": "frlg-back-shiny.png"}},
+			"generation-vi": {"x-y": {"front_default": "xy.png"}}
+		}
+	}}`
+	if err := json.Unmarshal([]byte(fixture), &pokemonData); err != nil {
+		t.Fatalf("could not decode fixture: %v", err)
+	}
+
+	cases := []struct {
+		variant  spriteVariant
+		expected string
+	}{
+		{variant: spriteVariant{}, expected: "front.png"},
+		{variant: spriteVariant{Shiny: true}, expected: "front-shiny.png"},
+		{variant: spriteVariant{Back: true}, expected: "back.png"},
+		{variant: spriteVariant{Female: true}, expected: "front-female.png"},
+		{variant: spriteVariant{Female: true, Back: true}, expected: "back.png"},
+		{variant: spriteVariant{Generation: 1}, expected: "rb-transparent.png"},
+		{variant: spriteVariant{Generation: 1, Shiny: true}, expected: ""},
+		{variant: spriteVariant{Generation: 3, Back: true, Shiny: true}, expected: "frlg-back-shiny.png"},
+		{variant: spriteVariant{Generation: 6}, expected: "xy.png"},
+		{variant: spriteVariant{Generation: 6, Back: true}, expected: ""},
+		{variant: spriteVariant{Generation: 9}, expected: ""},
+	}
+
+	for _, c := range cases {
+		if actual := pickSprite(pokemonData, c.variant); actual != c.expected {
+			t.Errorf("For %+v, expected %q but got %q", c.variant, c.expected, actual)
+		}
+	}
+}
+
+// testSprite is an 8x8 transparent image with a 2x4 block at (2, 2):
+// white on top and black at the bottom
+func testSprite() image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	for y := 2; y < 6; y++ {
+		for x := 2; x < 4; x++ {
+			c := color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+			if y >= 4 {
+				c = color.NRGBA{A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestCropTransparent(t *testing.T) {
+	expected := image.Rect(2, 2, 4, 6)
+	if actual := cropTransparent(testSprite()); actual != expected {
+		t.Errorf("expected %v but got %v", expected, actual)
+	}
+
+	empty := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	if actual := cropTransparent(empty); actual != empty.Bounds() {
+		t.Errorf("expected an empty image to keep its bounds, got %v", actual)
+	}
+}
+
+func TestRenderASCII(t *testing.T) {
+	expected := "..\n@@\n"
+	if actual := renderASCII(testSprite(), 0); actual != expected {
+		t.Errorf("expected %q but got %q", expected, actual)
+	}
+
+	if actual := renderASCII(testSprite(), 1); actual != ".\n" {
+		t.Errorf("expected the sprite scaled to one column, got %q", actual)
+	}
+}
+
+func TestRenderHalfBlocks(t *testing.T) {
+	actual := renderHalfBlocks(testSprite(), 0)
+
+	lines := strings.Split(strings.TrimSuffix(actual, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 4 pixel rows in 2 lines, got %q", actual)
+	}
+	if !strings.Contains(lines[0], "\x1b[38;2;255;255;255m\x1b[48;2;255;255;255m▀") {
+		t.Errorf("expected white on white in the first line, got %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "\x1b[38;2;0;0;0m\x1b[48;2;0;0;0m▀") {
+		t.Errorf("expected black on black in the second line, got %q", lines[1])
+	}
+}
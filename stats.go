@@ -0,0 +1,62 @@
+package main
+
+// statOrder is the order the games list stats in
+var statOrder = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+const maxStatEV = 252
+const maxTotalEV = 510
+
+func baseStats(pokemonData PokeData) map[string]int {
+	return savePokemon(pokemonData).Stats
+}
+
+// calcStat is the Generation III+ stat formula, natureModifier is 0.9, 1 or 1.1
+func calcStat(stat string, base, iv, ev, level int, natureModifier float64) int {
+	core := (2*base + iv + ev/4) * level / 100
+	if stat == "hp" {
+		return core + level + 10
+	}
+	return int(float64(core+5) * natureModifier)
+}
+
+// computeStats works out every stat at a level, missing IVs and EVs count as 0
+func computeStats(pokemonData PokeData, level int, ivs, evs map[string]int, nature Nature) map[string]int {
+	stats := make(map[string]int)
+	for stat, base := range baseStats(pokemonData) {
+		stats[stat] = calcStat(stat, base, ivs[stat], evs[stat], level, nature.modifier(stat))
+	}
+	return stats
+}
+
+// levelStats is computeStats for a pokemon with no IVs, EVs or nature
+func levelStats(pokemonData PokeData, level int) map[string]int {
+	return computeStats(pokemonData, level, nil, nil, Nature{})
+}
+
+// effortYield is the EVs a pokemon gives when it is defeated
+func effortYield(pokemonData PokeData) map[string]int {
+	yield := make(map[string]int)
+	for _, s := range pokemonData.Stats {
+		if s.Effort > 0 {
+			yield[s.Stat.Name] = s.Effort
+		}
+	}
+	return yield
+}
+
+// addEVs adds a yield on top of evs, respecting the per-stat and total caps
+func addEVs(evs, yield map[string]int) {
+	total := 0
+	for _, ev := range evs {
+		total += ev
+	}
+
+	for _, stat := range statOrder {
+		gain := yield[stat]
+		gain = min(gain, maxStatEV-evs[stat], maxTotalEV-total)
+		if gain > 0 {
+			evs[stat] += gain
+			total += gain
+		}
+	}
+}
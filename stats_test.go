@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCalcStat(t *testing.T) {
+	cases := []struct {
+		stat     string
+		base     int
+		iv       int
+		ev       int
+		level    int
+		nature   float64
+		expected int
+	}{
+		{stat: "hp", base: 108, iv: 24, ev: 74, level: 78, expected: 289},
+		{stat: "attack", base: 130, iv: 12, ev: 190, level: 78, expected: 253},
+		{stat: "hp", base: 35, level: 5, expected: 18},
+		{stat: "speed", base: 90, level: 5, expected: 14},
+		{stat: "hp", base: 1, iv: 31, ev: 252, level: 100, expected: 206},
+		{stat: "attack", base: 130, iv: 31, ev: 252, level: 100, nature: 1.1, expected: 394},
+		{stat: "speed", base: 102, iv: 31, ev: 0, level: 100, nature: 0.9, expected: 216},
+	}
+
+	for _, c := range cases {
+		if c.nature == 0 {
+			c.nature = 1
+		}
+		if actual := calcStat(c.stat, c.base, c.iv, c.ev, c.level, c.nature); actual != c.expected {
+			t.Errorf("For %+v, expected %v but got %v", c, c.expected, actual)
+		}
+	}
+}
+
+func TestAddEVs(t *testing.T) {
+	cases := []struct {
+		evs      map[string]int
+		yield    map[string]int
+		expected map[string]int
+	}{
+		{
+			evs:      map[string]int{},
+			yield:    map[string]int{"speed": 2},
+			expected: map[string]int{"speed": 2},
+		},
+		{
+			evs:      map[string]int{"speed": 251},
+			yield:    map[string]int{"speed": 3},
+			expected: map[string]int{"speed": 252},
+		},
+		{
+			evs:      map[string]int{"attack": 252, "speed": 252, "hp": 5},
+			yield:    map[string]int{"hp": 3},
+			expected: map[string]int{"attack": 252, "speed": 252, "hp": 6},
+		},
+	}
+
+	for _, c := range cases {
+		addEVs(c.evs, c.yield)
+		for stat, value := range c.expected {
+			if c.evs[stat] != value {
+				t.Errorf("expected %v EVs in %v but got %v", value, stat, c.evs[stat])
+			}
+		}
+	}
+}
+
+func TestLevelForExperience(t *testing.T) {
+	var growth PokeGrowthRate
+	fixture := `{"name": "medium", "levels": [
+		{"level": 1, "experience": 0},
+		{"level": 2, "experience": 8},
+		{"level": 3, "experience": 27},
+		{"level": 4, "experience": 64}
+	]}`
+	if err := json.Unmarshal([]byte(fixture), &growth); err != nil {
+		t.Fatalf("could not decode fixture: %v", err)
+	}
+
+	cases := map[int]int{
+		0:   1,
+		7:   1,
+		8:   2,
+		63:  3,
+		100: 4,
+	}
+
+	for experience, expected := range cases {
+		if actual := growth.levelForExperience(experience); actual != expected {
+			t.Errorf("For %v experience, expected level %v but got %v", experience, expected, actual)
+		}
+	}
+
+	if actual := growth.experienceForLevel(3); actual != 27 {
+		t.Errorf("expected level 3 at 27 experience but got %v", actual)
+	}
+}
+
+func TestExperienceYield(t *testing.T) {
+	if actual := experienceYield(64, 5, false); actual != 45 {
+		t.Errorf("expected 45 but got %v", actual)
+	}
+	if actual := experienceYield(64, 5, true); actual != 67 {
+		t.Errorf("expected 67 but got %v", actual)
+	}
+}
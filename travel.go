@@ -0,0 +1,231 @@
+package main
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+)
+
+// New trainers start their journey here
+const startingPosition = "pallet-town"
+
+//go:embed routes.txt
+var routesFile string
+
+// routes connects the locations that can be walked between
+var routes = parseRoutes(routesFile)
+
+// routeGraph lists the neighbours of each location, in the order they were read
+type routeGraph map[string][]string
+
+func parseRoutes(text string) routeGraph {
+	graph := routeGraph{}
+	for _, line := range strings.Split(text, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		a, b := fields[0], fields[1]
+		if !containsString(graph[a], b) {
+			graph[a] = append(graph[a], b)
+			graph[b] = append(graph[b], a)
+		}
+	}
+	return graph
+}
+
+// covers is whether the route map knows a location. Only Kanto is mapped,
+// so travel anywhere else goes straight to the destination.
+func (g routeGraph) covers(location string) bool {
+	_, ok := g[location]
+	return ok
+}
+
+// shortestPath finds the fewest steps between two locations with a breadth
+// first search. The path includes both ends and is nil when there's none.
+func (g routeGraph) shortestPath(from, to string) []string {
+	if _, ok := g[from]; !ok {
+		return nil
+	}
+	if from == to {
+		return []string{from}
+	}
+
+	previous := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, next := range g[current] {
+			if _, seen := previous[next]; seen {
+				continue
+			}
+			previous[next] = current
+
+			if next == to {
+				path := []string{to}
+				for step := current; step != ""; step = previous[step] {
+					path = append([]string{step}, path...)
+				}
+				return path
+			}
+			queue = append(queue, next)
+		}
+	}
+	return nil
+}
+
+// resolveDestination accepts a location, or one of its areas which is then
+// where the trainer ends up
+func resolveDestination(param *config, name string) (PokeLocationInfo, *PokeLocation, error) {
+	name = resolveName(param, name, "location", "location-area")
+
+	if location, err := fetchLocation(param, name); err == nil {
+		return location, nil, nil
+	}
+
+	area, err := fetchLocationArea(param, name)
+	if err != nil {
+		return PokeLocationInfo{}, nil, err
+	}
+	location, err := fetchLocation(param, area.Location.Name)
+	return location, &area, err
+}
+
+// moveTo puts the trainer in a location, in the given area or else its first one
+func moveTo(param *config, location PokeLocationInfo, area *PokeLocation) error {
+	if area == nil && len(location.Areas) > 0 {
+		first, err := fetchLocationArea(param, location.Areas[0].Name)
+		if err != nil {
+			return err
+		}
+		area = &first
+	}
+
+	param.Position = location.Name
+	param.CurrentArea = area
+	param.Location = ""
+	if area != nil {
+		param.Location = area.Name
+	}
+	param.Wild = nil
+	return nil
+}
+
+// restorePosition puts a loaded trainer back in their saved area, or the
+// first area of their location when the save didn't have one
+func restorePosition(param *config) error {
+	if param.Position == "" {
+		return nil
+	}
+
+	location, err := fetchLocation(param, param.Position)
+	if err != nil {
+		return err
+	}
+
+	var area *PokeLocation
+	if param.Location != "" {
+		saved, err := fetchLocationArea(param, param.Location)
+		if err != nil {
+			return err
+		}
+		area = &saved
+	}
+	return moveTo(param, location, area)
+}
+
+func commandTravel(param *config) error {
+	if inBattle(param) {
+		return nil
+	}
+	if len(param.Args) == 0 {
+		fmt.Println("Where do you want to travel to?")
+		return nil
+	}
+
+	location, area, err := resolveDestination(param, strings.Join(param.Args, " "))
+	if err != nil {
+		reportUnknown(param, strings.Join(param.Args, " "), "place", "location")
+		return err
+	}
+
+	path := routes.shortestPath(param.Position, location.Name)
+	switch {
+	case path != nil:
+		for _, step := range path[1 : len(path)-1] {
+			fmt.Printf("Passing through %v...\n", localLabel(param, "location", step))
+		}
+	case !routes.covers(param.Position) || !routes.covers(location.Name):
+		fmt.Println("There's no route map for this part of the world, so you head straight there")
+	case param.Sandbox:
+		fmt.Println("Flying there, sandbox mode ignores the routes")
+	default:
+		fmt.Printf("There's no known route from %v to %v\n", localLabel(param, "location", param.Position), localLabel(param, "location", location.Name))
+		return nil
+	}
+
+	if err := moveTo(param, location, area); err != nil {
+		fmt.Println("Error loading the area from pokeAPI")
+		return err
+	}
+
+	fmt.Printf("You traveled to %v\n", localLabel(param, "location", location.Name))
+	if param.CurrentArea != nil {
+		fmt.Printf("You're in %v\n", localLabel(param, "location-area", param.CurrentArea.Name))
+	}
+	return nil
+}
+
+func commandWhere(param *config) error {
+	if param.Position == "" {
+		fmt.Println("You aren't anywhere yet! Use travel to pick a location")
+		return nil
+	}
+
+	fmt.Printf("You're at %v\n", localLabel(param, "location", param.Position))
+	if param.CurrentArea != nil {
+		fmt.Printf("Area: %v\n", localLabel(param, "location-area", param.CurrentArea.Name))
+	}
+
+	neighbours := []string{}
+	for _, next := range routes[param.Position] {
+		neighbours = append(neighbours, localLabel(param, "location", next))
+	}
+	if len(neighbours) > 0 {
+		fmt.Printf("From here you can go to: %v\n", strings.Join(neighbours, ", "))
+	}
+	return nil
+}
+
+func commandRoute(param *config) error {
+	from, to := param.Position, ""
+	switch len(param.Args) {
+	case 1:
+		to = param.Args[0]
+	case 2:
+		from, to = param.Args[0], param.Args[1]
+	default:
+		fmt.Println("Usage: route [from] <to>")
+		return nil
+	}
+	from, to = resolveName(param, from, "location"), resolveName(param, to, "location")
+
+	path := routes.shortestPath(from, to)
+	if path == nil && (!routes.covers(from) || !routes.covers(to)) {
+		fmt.Printf("There's no route map between %v and %v, travel goes straight there\n", orUnknown(from), to)
+		return nil
+	}
+	if path == nil {
+		fmt.Printf("There's no known route from %v to %v\n", orUnknown(from), to)
+		return nil
+	}
+
+	steps := []string{}
+	for _, step := range path {
+		steps = append(steps, localLabel(param, "location", step))
+	}
+	fmt.Printf("%d steps: %v\n", len(path)-1, strings.Join(steps, " -> "))
+	return nil
+}
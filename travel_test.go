@@ -0,0 +1,119 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestShortestPath(t *testing.T) {
+	graph := parseRoutes(`# a small loop with a spur
+a b
+b c
+c d
+a e
+e d
+d f
+`)
+
+	cases := []struct {
+		from, to string
+		expected []string
+	}{
+		{from: "a", to: "a", expected: []string{"a"}},
+		{from: "a", to: "b", expected: []string{"a", "b"}},
+		{from: "a", to: "d", expected: []string{"a", "e", "d"}},
+		{from: "f", to: "b", expected: []string{"f", "d", "c", "b"}},
+		{from: "a", to: "z", expected: nil},
+		{from: "z", to: "a", expected: nil},
+	}
+
+	for _, c := range cases {
+		if actual := graph.shortestPath(c.from, c.to); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("From %v to %v, expected %v but got %v", c.from, c.to, c.expected, actual)
+		}
+	}
+}
+
+func TestBundledRoutes(t *testing.T) {
+	if _, ok := routes[startingPosition]; !ok {
+		t.Fatalf("expected the starting position %v in the route graph", startingPosition)
+	}
+
+	path := routes.shortestPath(startingPosition, "indigo-plateau")
+	if path == nil {
+		t.Fatalf("expected a route from %v to indigo-plateau", startingPosition)
+	}
+
+	expected := []string{"pallet-town", "kanto-route-1", "viridian-city", "kanto-route-22", "kanto-route-23", "kanto-victory-road-1", "indigo-plateau"}
+	if !reflect.DeepEqual(path, expected) {
+		t.Errorf("expected %v but got %v", expected, path)
+	}
+}
+
+// travelFixtures are a Kanto town on the route map and a Sinnoh one off it
+var travelFixtures = map[string]string{
+	"https://pokeapi.co/api/v2/location/pallet-town":             `{"name": "pallet-town", "region": {"name": "kanto"}, "areas": [{"name": "pallet-town-area"}]}`,
+	"https://pokeapi.co/api/v2/location-area/pallet-town-area":   `{"name": "pallet-town-area", "location": {"name": "pallet-town"}}`,
+	"https://pokeapi.co/api/v2/location/viridian-city":           `{"name": "viridian-city", "region": {"name": "kanto"}, "areas": [{"name": "viridian-city-area"}]}`,
+	"https://pokeapi.co/api/v2/location-area/viridian-city-area": `{"name": "viridian-city-area", "location": {"name": "viridian-city"}}`,
+	"https://pokeapi.co/api/v2/location/canalave-city":           `{"name": "canalave-city", "region": {"name": "sinnoh"}, "areas": [{"name": "canalave-city-area"}]}`,
+	"https://pokeapi.co/api/v2/location-area/canalave-city-area": `{"name": "canalave-city-area", "location": {"name": "canalave-city"}}`,
+}
+
+func TestTravelOffTheRouteMap(t *testing.T) {
+	param := &config{Cache: seedCache(travelFixtures), Position: startingPosition}
+
+	cases := []struct {
+		destination string
+		expected    string
+	}{
+		{destination: "viridian-city", expected: "viridian-city"},
+		{destination: "canalave-city", expected: "canalave-city"},
+		{destination: "pallet-town", expected: "pallet-town"},
+	}
+
+	for _, c := range cases {
+		from := param.Position
+		param.Args = []string{c.destination}
+		if err := commandTravel(param); err != nil {
+			t.Fatalf("From %v to %v, unexpected error %v", from, c.destination, err)
+		}
+		if param.Position != c.expected || param.CurrentArea == nil || param.CurrentArea.Name != c.expected+"-area" {
+			t.Errorf("From %v to %v, expected to end up in %v but got %v", from, c.destination, c.expected, param.Position)
+		}
+	}
+}
+
+func TestRestorePosition(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+
+	original := &config{SavePath: path, Cache: seedCache(travelFixtures), Position: startingPosition}
+	original.Args = []string{"viridian-city-area"}
+	if err := commandTravel(original); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := saveGame(original); err != nil {
+		t.Fatalf("could not save: %v", err)
+	}
+
+	loaded := &config{SavePath: path, Cache: seedCache(travelFixtures), Position: startingPosition}
+	if err := loadGame(loaded); err != nil {
+		t.Fatalf("could not load: %v", err)
+	}
+	if err := restorePosition(loaded); err != nil {
+		t.Fatalf("could not restore the position: %v", err)
+	}
+	if loaded.Position != "viridian-city" || loaded.CurrentArea == nil || loaded.CurrentArea.Name != "viridian-city-area" {
+		t.Errorf("expected to be back in viridian-city-area, got %v", loaded.Location)
+	}
+
+	// Saves from before positions were kept start in the first area of the starting town
+	fresh := &config{Cache: seedCache(travelFixtures), Position: startingPosition}
+	if err := restorePosition(fresh); err != nil {
+		t.Fatalf("could not restore the position: %v", err)
+	}
+	if fresh.CurrentArea == nil || fresh.CurrentArea.Name != "pallet-town-area" {
+		t.Errorf("expected to start in pallet-town-area, got %v", fresh.Location)
+	}
+}
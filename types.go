@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var allTypes = []string{
+	"normal", "fire", "water", "electric", "grass", "ice",
+	"fighting", "poison", "ground", "flying", "psychic", "bug",
+	"rock", "ghost", "dragon", "dark", "steel", "fairy",
+}
+
+type PokeType struct {
+	ID              int    `json:"id"`
+	Name            string `json:"name"`
+	DamageRelations struct {
+		DoubleDamageTo []NamedAPIResource `json:"double_damage_to"`
+		HalfDamageTo   []NamedAPIResource `json:"half_damage_to"`
+		NoDamageTo     []NamedAPIResource `json:"no_damage_to"`
+	} `json:"damage_relations"`
+}
+
+// TypeChart is the 18x18 matrix of attacking type (row) against defending type (column)
+type TypeChart [18][18]float64
+
+func typeIndex(name string) int {
+	for i, t := range allTypes {
+		if t == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// buildTypeChart fills the matrix from each type's damage_relations
+func buildTypeChart(types []PokeType) *TypeChart {
+	chart := &TypeChart{}
+	for i := range chart {
+		for j := range chart[i] {
+			chart[i][j] = 1
+		}
+	}
+
+	set := func(attacker string, defenders []NamedAPIResource, multiplier float64) {
+		for _, defender := range defenders {
+			if a, d := typeIndex(attacker), typeIndex(defender.Name); a >= 0 && d >= 0 {
+				chart[a][d] = multiplier
+			}
+		}
+	}
+
+	for _, t := range types {
+		set(t.Name, t.DamageRelations.DoubleDamageTo, 2)
+		set(t.Name, t.DamageRelations.HalfDamageTo, 0.5)
+		set(t.Name, t.DamageRelations.NoDamageTo, 0)
+	}
+	return chart
+}
+
+// effectiveness multiplies the matchup against every defending type, so a
+// dual-type pokemon can take 4x or 0.25x. A nil chart or unknown type is neutral.
+func (c *TypeChart) effectiveness(attackType string, defenderTypes []string) float64 {
+	multiplier := 1.0
+	if c == nil {
+		return multiplier
+	}
+
+	a := typeIndex(attackType)
+	if a < 0 {
+		return multiplier
+	}
+	for _, defender := range defenderTypes {
+		if d := typeIndex(defender); d >= 0 {
+			multiplier *= c[a][d]
+		}
+	}
+	return multiplier
+}
+
+// loadTypeChart fetches all 18 types once per session
+func loadTypeChart(param *config) (*TypeChart, error) {
+	if param.TypeChart != nil {
+		return param.TypeChart, nil
+	}
+
+	types := []PokeType{}
+	for _, name := range allTypes {
+		body, err := fetchData(param, fmt.Sprintf("https://pokeapi.co/api/v2/type/%v", name))
+		if err != nil {
+			return nil, err
+		}
+
+		var t PokeType
+		if err := json.Unmarshal(body, &t); err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+	}
+
+	param.TypeChart = buildTypeChart(types)
+	return param.TypeChart, nil
+}
+
+// lookupPokemon finds a caught pokemon by ID, nickname or species, or else
+// fetches the species from PokeAPI.
+func lookupPokemon(param *config, ref string) (PokeData, error) {
+	if pokemon, err := findCaught(param, ref); err == nil {
+		err := pokemon.loadData(param)
+		return pokemon.Data, err
+	}
+	return fetchPokemon(param, resolveName(param, ref, "pokemon-species"))
+}
+
+func commandWeakness(param *config) error {
+	if len(param.Args) == 0 {
+		fmt.Println("Which pokemon should be checked?")
+		return nil
+	}
+
+	pokemonData, err := lookupPokemon(param, param.Args[0])
+	if err != nil {
+		fmt.Println("Error finding the pokemon, Make sure it exists")
+		return err
+	}
+
+	chart, err := loadTypeChart(param)
+	if err != nil {
+		fmt.Println("Error loading the type chart")
+		return err
+	}
+
+	defenderTypes := savePokemon(pokemonData).Types
+	byMultiplier := make(map[float64][]string)
+	for _, attackType := range allTypes {
+		multiplier := chart.effectiveness(attackType, defenderTypes)
+		byMultiplier[multiplier] = append(byMultiplier[multiplier], attackType)
+	}
+
+	multipliers := []float64{}
+	for multiplier := range byMultiplier {
+		multipliers = append(multipliers, multiplier)
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(multipliers)))
+
+	fmt.Printf("Damage taken by %v (%v):\n", pokemonData.Name, strings.Join(defenderTypes, "/"))
+	for _, multiplier := range multipliers {
+		fmt.Printf(" %5gx: %v\n", multiplier, strings.Join(byMultiplier[multiplier], ", "))
+	}
+	return nil
+}
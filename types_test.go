@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+)
+
+func testTypeChart() *TypeChart {
+	relation := func(names ...string) []NamedAPIResource {
+		list := []NamedAPIResource{}
+		for _, name := range names {
+			list = append(list, NamedAPIResource{Name: name})
+		}
+		return list
+	}
+
+	var water, electric, ground, grass PokeType
+	water.Name = "water"
+	water.DamageRelations.DoubleDamageTo = relation("fire", "ground", "rock")
+	water.DamageRelations.HalfDamageTo = relation("water", "grass", "dragon")
+
+	electric.Name = "electric"
+	electric.DamageRelations.DoubleDamageTo = relation("water", "flying")
+	electric.DamageRelations.HalfDamageTo = relation("electric", "grass", "dragon")
+	electric.DamageRelations.NoDamageTo = relation("ground")
+
+	ground.Name = "ground"
+	ground.DamageRelations.DoubleDamageTo = relation("fire", "electric", "poison", "rock", "steel")
+	ground.DamageRelations.NoDamageTo = relation("flying")
+
+	grass.Name = "grass"
+	grass.DamageRelations.DoubleDamageTo = relation("water", "ground", "rock")
+	grass.DamageRelations.HalfDamageTo = relation("fire", "grass", "poison", "flying", "bug", "dragon", "steel")
+
+	return buildTypeChart([]PokeType{water, electric, ground, grass})
+}
+
+func TestEffectiveness(t *testing.T) {
+	chart := testTypeChart()
+
+	cases := []struct {
+		attack   string
+		defender []string
+		expected float64
+	}{
+		{attack: "water", defender: []string{"fire"}, expected: 2},
+		{attack: "water", defender: []string{"rock", "ground"}, expected: 4},
+		{attack: "grass", defender: []string{"fire", "flying"}, expected: 0.25},
+		{attack: "electric", defender: []string{"water", "ground"}, expected: 0},
+		{attack: "ground", defender: []string{"electric", "flying"}, expected: 0},
+		{attack: "water", defender: []string{"normal"}, expected: 1},
+		{attack: "fire", defender: []string{"grass"}, expected: 1},
+		{attack: "shadow", defender: []string{"grass"}, expected: 1},
+	}
+
+	for _, c := range cases {
+		if actual := chart.effectiveness(c.attack, c.defender); actual != c.expected {
+			t.Errorf("For %v against %v, expected %v but got %v", c.attack, c.defender, c.expected, actual)
+		}
+	}
+}
+
+func TestNilTypeChartIsNeutral(t *testing.T) {
+	var chart *TypeChart
+	if actual := chart.effectiveness("water", []string{"fire"}); actual != 1 {
+		t.Errorf("expected 1 but got %v", actual)
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type PokeVersion struct {
+	ID           int              `json:"id"`
+	Name         string           `json:"name"`
+	VersionGroup NamedAPIResource `json:"version_group"`
+}
+
+type PokeVersionGroup struct {
+	ID         int                `json:"id"`
+	Name       string             `json:"name"`
+	Generation NamedAPIResource   `json:"generation"`
+	Versions   []NamedAPIResource `json:"versions"`
+	Pokedexes  []NamedAPIResource `json:"pokedexes"`
+}
+
+var romanNumerals = map[string]int{
+	"i": 1, "ii": 2, "iii": 3, "iv": 4, "v": 5,
+	"vi": 6, "vii": 7, "viii": 8, "ix": 9, "x": 10,
+}
+
+// generationNumber turns "generation-iii" into 3, or 0 when unknown
+func generationNumber(name string) int {
+	return romanNumerals[strings.TrimPrefix(name, "generation-")]
+}
+
+func fetchVersion(param *config, name string) (PokeVersion, PokeVersionGroup, error) {
+	var version PokeVersion
+	var group PokeVersionGroup
+
+	body, err := fetchData(param, fmt.Sprintf("https://pokeapi.co/api/v2/version/%v", name))
+	if err != nil {
+		return version, group, err
+	}
+	if err := json.Unmarshal(body, &version); err != nil {
+		return version, group, err
+	}
+
+	body, err = fetchData(param, version.VersionGroup.URL)
+	if err != nil {
+		return version, group, err
+	}
+	if err := json.Unmarshal(body, &group); err != nil {
+		return version, group, err
+	}
+	return version, group, nil
+}
+
+// existsInVersion reports whether a species can be found in a game.
+// game_indices cover every game up to Black 2/White 2, newer games fall
+// back to comparing the generation the species was introduced in.
+func existsInVersion(pokemonData PokeData, species PokeSpecies, version string, versionGeneration int) bool {
+	for _, index := range pokemonData.GameIndices {
+		if index.Version.Name == version {
+			return true
+		}
+	}
+
+	if versionGeneration <= 5 {
+		return false
+	}
+	return generationNumber(species.Generation.Name) <= versionGeneration
+}
+
+// versionMoves lists the moves a pokemon can learn in a version group
+func versionMoves(pokemonData PokeData, versionGroup string) []string {
+	moves := []string{}
+	for _, move := range pokemonData.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if detail.VersionGroup.Name == versionGroup {
+				moves = append(moves, move.Move.Name)
+				break
+			}
+		}
+	}
+	return moves
+}
+
+// versionHeldItems lists the items a wild pokemon may hold in a version, with their rarity
+func versionHeldItems(pokemonData PokeData, version string) []string {
+	items := []string{}
+	for _, held := range pokemonData.HeldItems {
+		for _, detail := range held.VersionDetails {
+			if detail.Version.Name == version {
+				items = append(items, fmt.Sprintf("%v (%d%%)", held.Item.Name, detail.Rarity))
+			}
+		}
+	}
+	return items
+}
+
+func commandVersion(param *config) error {
+	if len(param.Args) == 0 {
+		if param.Version == "" {
+			fmt.Println("No game version selected, showing data from every game")
+		} else {
+			fmt.Printf("Current version: %v (%v, generation %d)\n", param.Version, param.VersionGroup, param.Generation)
+		}
+		return nil
+	}
+
+	if param.Args[0] == "any" || param.Args[0] == "all" {
+		param.Version = ""
+		param.VersionGroup = ""
+		param.Generation = 0
+		fmt.Println("Showing data from every game")
+		return nil
+	}
+
+	version, group, err := fetchVersion(param, param.Args[0])
+	if err != nil {
+		fmt.Println("Error finding that version, make sure it exists")
+		return err
+	}
+
+	param.Version = version.Name
+	param.VersionGroup = group.Name
+	param.Generation = generationNumber(group.Generation.Name)
+	param.Wild = nil
+
+	fmt.Printf("Version set to %v (%v, generation %d)\n", param.Version, param.VersionGroup, param.Generation)
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGenerationNumber(t *testing.T) {
+	cases := map[string]int{
+		"generation-i":    1,
+		"generation-iv":   4,
+		"generation-viii": 8,
+		"generation-ix":   9,
+		"unknown":         0,
+	}
+
+	for name, expected := range cases {
+		if actual := generationNumber(name); actual != expected {
+			t.Errorf("For %q, expected %v but got %v", name, expected, actual)
+		}
+	}
+}
+
+func TestExistsInVersion(t *testing.T) {
+	var pokemonData PokeData
+	fixture := `{"game_indices": [{"game_index": 161, "version": {"name": "gold"}}]}`
+	if err := json.Unmarshal([]byte(fixture), &pokemonData); err != nil {
+		t.Fatalf("could not decode fixture: %v", err)
+	}
+
+	species := PokeSpecies{Generation: NamedAPIResource{Name: "generation-ii"}}
+
+	cases := []struct {
+		version    string
+		generation int
+		expected   bool
+	}{
+		{version: "gold", generation: 2, expected: true},
+		{version: "red", generation: 1, expected: false},
+		{version: "emerald", generation: 3, expected: false},
+		{version: "x", generation: 6, expected: true},
+	}
+
+	for _, c := range cases {
+		if actual := existsInVersion(pokemonData, species, c.version, c.generation); actual != c.expected {
+			t.Errorf("For version %v, expected %v but got %v", c.version, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AreaEncounter is one entry of a pokemon's location_area_encounters list
+type AreaEncounter struct {
+	LocationArea   NamedAPIResource `json:"location_area"`
+	VersionDetails []struct {
+		Version          NamedAPIResource `json:"version"`
+		MaxChance        int              `json:"max_chance"`
+		EncounterDetails []struct {
+			MinLevel        int                `json:"min_level"`
+			MaxLevel        int                `json:"max_level"`
+			Chance          int                `json:"chance"`
+			Method          NamedAPIResource   `json:"method"`
+			ConditionValues []NamedAPIResource `json:"condition_values"`
+		} `json:"encounter_details"`
+	} `json:"version_details"`
+}
+
+// encounterSpot sums up the encounter slots of one area, version, method and
+// set of conditions, like time of day, a swarm or the Poké Radar
+type encounterSpot struct {
+	Area       string
+	Version    string
+	Method     string
+	Conditions string // Sorted condition values joined by ", ", empty when always available
+	MinLevel   int
+	MaxLevel   int
+	Chance     int
+}
+
+// spotSorts orders spots for the --sort flag of where-to-find
+var spotSorts = map[string]func(a, b encounterSpot) bool{
+	"area":   func(a, b encounterSpot) bool { return a.Area < b.Area },
+	"chance": func(a, b encounterSpot) bool { return a.Chance > b.Chance },
+	"level":  func(a, b encounterSpot) bool { return a.MinLevel < b.MinLevel },
+}
+
+func fetchAreaEncounters(param *config, pokemonData PokeData) ([]AreaEncounter, error) {
+	var encounters []AreaEncounter
+
+	body, err := fetchData(param, pokemonData.LocationAreaEncounters)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(body, &encounters); err != nil {
+		return nil, err
+	}
+	return encounters, nil
+}
+
+// encounterSpots merges the slots of each area, version, method and set of
+// conditions, adding up their chances and widening the level range. Slots
+// under different conditions never appear together, so they stay apart. An
+// empty version keeps them all.
+func encounterSpots(encounters []AreaEncounter, version string) []encounterSpot {
+	spots := []encounterSpot{}
+	index := map[[4]string]int{}
+
+	for _, encounter := range encounters {
+		for _, versionDetail := range encounter.VersionDetails {
+			if version != "" && versionDetail.Version.Name != version {
+				continue
+			}
+
+			for _, detail := range versionDetail.EncounterDetails {
+				conditions := []string{}
+				for _, condition := range detail.ConditionValues {
+					conditions = append(conditions, condition.Name)
+				}
+				sort.Strings(conditions)
+
+				key := [4]string{encounter.LocationArea.Name, versionDetail.Version.Name, detail.Method.Name, strings.Join(conditions, ", ")}
+
+				i, ok := index[key]
+				if !ok {
+					i = len(spots)
+					index[key] = i
+					spots = append(spots, encounterSpot{
+						Area:       key[0],
+						Version:    key[1],
+						Method:     key[2],
+						Conditions: key[3],
+						MinLevel:   detail.MinLevel,
+						MaxLevel:   detail.MaxLevel,
+					})
+				}
+
+				spot := &spots[i]
+				spot.MinLevel = min(spot.MinLevel, detail.MinLevel)
+				spot.MaxLevel = max(spot.MaxLevel, detail.MaxLevel)
+				spot.Chance += detail.Chance
+			}
+		}
+	}
+	return spots
+}
+
+// groupSpots splits spots by version, in the order versions first appear,
+// and sorts each group by less, within each method when byMethod is set
+func groupSpots(spots []encounterSpot, less func(a, b encounterSpot) bool, byMethod bool) ([]string, map[string][]encounterSpot) {
+	versions := []string{}
+	groups := map[string][]encounterSpot{}
+
+	for _, spot := range spots {
+		if _, ok := groups[spot.Version]; !ok {
+			versions = append(versions, spot.Version)
+		}
+		groups[spot.Version] = append(groups[spot.Version], spot)
+	}
+
+	for _, group := range groups {
+		sort.SliceStable(group, func(i, j int) bool {
+			if byMethod && group[i].Method != group[j].Method {
+				return group[i].Method < group[j].Method
+			}
+			return less(group[i], group[j])
+		})
+	}
+	return versions, groups
+}
+
+// conditions is " (...)" listing what has to hold for the spot, or "" when nothing does
+func (spot encounterSpot) conditions() string {
+	if spot.Conditions == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (%v)", spot.Conditions)
+}
+
+func (spot encounterSpot) levels() string {
+	if spot.MinLevel == spot.MaxLevel {
+		return fmt.Sprintf("Lv%d", spot.MinLevel)
+	}
+	return fmt.Sprintf("Lv%d-%d", spot.MinLevel, spot.MaxLevel)
+}
+
+func commandWhereToFind(param *config) error {
+	positional, flags := parseArgs(param.Args)
+	if len(positional) == 0 {
+		fmt.Println("Usage: where-to-find <pokemon> [--version <version>|any] [--sort area|chance|level]")
+		return nil
+	}
+
+	sortBy := "area"
+	if s, ok := flags["sort"]; ok {
+		sortBy = s
+	}
+	less, ok := spotSorts[sortBy]
+	if !ok {
+		fmt.Printf("Unknown sort %v, use area, chance or level\n", sortBy)
+		return nil
+	}
+
+	version := param.Version
+	if v, ok := flags["version"]; ok {
+		version = v
+		if v == "any" || v == "all" {
+			version = ""
+		}
+	}
+
+	pokemonData, err := lookupPokemon(param, strings.Join(positional, " "))
+	if err != nil {
+		fmt.Println("Error finding the pokemon, Make sure it exists")
+		return err
+	}
+
+	encounters, err := fetchAreaEncounters(param, pokemonData)
+	if err != nil {
+		fmt.Println("Error loading the encounters from pokeAPI")
+		return err
+	}
+
+	spots := encounterSpots(encounters, version)
+	if len(spots) == 0 {
+		if version == "" {
+			fmt.Printf("%v can't be found in the wild\n", pokemonData.Name)
+		} else {
+			fmt.Printf("%v can't be found in the wild in %v\n", pokemonData.Name, version)
+		}
+		return nil
+	}
+
+	// The best odds are wanted across every method, not within each one
+	byMethod := sortBy != "chance"
+
+	versions, groups := groupSpots(spots, less, byMethod)
+	for _, v := range versions {
+		fmt.Printf("%v:\n", v)
+		method := ""
+		for _, spot := range groups[v] {
+			if !byMethod {
+				fmt.Printf("  - %v %v, %d%% (%v)%v\n", localLabel(param, "location-area", spot.Area), spot.levels(), spot.Chance, spot.Method, spot.conditions())
+				continue
+			}
+			if spot.Method != method {
+				method = spot.Method
+				fmt.Printf("  %v:\n", method)
+			}
+			fmt.Printf("    - %v %v, %d%%%v\n", localLabel(param, "location-area", spot.Area), spot.levels(), spot.Chance, spot.conditions())
+		}
+	}
+	return nil
+}
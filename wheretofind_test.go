@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const areaEncountersFixture = `[
+	{"location_area": {"name": "viridian-forest-area"}, "version_details": [
+		{"version": {"name": "red"}, "max_chance": 5, "encounter_details": [
+			{"min_level": 3, "max_level": 3, "chance": 5, "method": {"name": "walk"}}
+		]},
+		{"version": {"name": "yellow"}, "max_chance": 20, "encounter_details": [
+			{"min_level": 3, "max_level": 3, "chance": 15, "method": {"name": "walk"}},
+			{"min_level": 5, "max_level": 5, "chance": 5, "method": {"name": "walk"}}
+		]}
+	]},
+	{"location_area": {"name": "power-plant-area"}, "version_details": [
+		{"version": {"name": "red"}, "max_chance": 40, "encounter_details": [
+			{"min_level": 21, "max_level": 21, "chance": 25, "method": {"name": "walk"}},
+			{"min_level": 24, "max_level": 24, "chance": 15, "method": {"name": "walk"}}
+		]}
+	]}
+]`
+
+// conditionEncountersFixture has slots that only show up at some times of
+// day, during a swarm or with the Poké Radar
+const conditionEncountersFixture = `[
+	{"location_area": {"name": "sinnoh-route-201-area"}, "version_details": [
+		{"version": {"name": "diamond"}, "max_chance": 60, "encounter_details": [
+			{"min_level": 2, "max_level": 2, "chance": 10, "method": {"name": "walk"}, "condition_values": [{"name": "time-morning"}]},
+			{"min_level": 3, "max_level": 3, "chance": 10, "method": {"name": "walk"}, "condition_values": [{"name": "time-morning"}]},
+			{"min_level": 4, "max_level": 4, "chance": 5, "method": {"name": "walk"}, "condition_values": [{"name": "time-night"}]},
+			{"min_level": 5, "max_level": 5, "chance": 15, "method": {"name": "walk"}, "condition_values": [{"name": "swarm-yes"}, {"name": "radar-off"}]},
+			{"min_level": 5, "max_level": 5, "chance": 15, "method": {"name": "walk"}, "condition_values": [{"name": "radar-off"}, {"name": "swarm-yes"}]},
+			{"min_level": 4, "max_level": 4, "chance": 5, "method": {"name": "walk"}}
+		]}
+	]}
+]`
+
+func TestEncounterSpots(t *testing.T) {
+	var encounters []AreaEncounter
+	if err := json.Unmarshal([]byte(areaEncountersFixture), &encounters); err != nil {
+		t.Fatalf("could not decode fixture: %v", err)
+	}
+
+	expected := []encounterSpot{
+		{Area: "viridian-forest-area", Version: "red", Method: "walk", MinLevel: 3, MaxLevel: 3, Chance: 5},
+		{Area: "power-plant-area", Version: "red", Method: "walk", MinLevel: 21, MaxLevel: 24, Chance: 40},
+	}
+	if actual := encounterSpots(encounters, "red"); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v but got %v", expected, actual)
+	}
+
+	all := encounterSpots(encounters, "")
+	if len(all) != 3 {
+		t.Fatalf("expected 3 spots across every version but got %v", all)
+	}
+	if yellow := all[1]; yellow.Version != "yellow" || yellow.Chance != 20 || yellow.levels() != "Lv3-5" {
+		t.Errorf("expected the yellow slots merged to Lv3-5 at 20%%, got %v", yellow)
+	}
+
+	if actual := encounterSpots(encounters, "gold"); len(actual) != 0 {
+		t.Errorf("expected no spots in gold but got %v", actual)
+	}
+}
+
+func TestEncounterSpotsConditions(t *testing.T) {
+	var encounters []AreaEncounter
+	if err := json.Unmarshal([]byte(conditionEncountersFixture), &encounters); err != nil {
+		t.Fatalf("could not decode fixture: %v", err)
+	}
+
+	area := "sinnoh-route-201-area"
+	expected := []encounterSpot{
+		{Area: area, Version: "diamond", Method: "walk", Conditions: "time-morning", MinLevel: 2, MaxLevel: 3, Chance: 20},
+		{Area: area, Version: "diamond", Method: "walk", Conditions: "time-night", MinLevel: 4, MaxLevel: 4, Chance: 5},
+		{Area: area, Version: "diamond", Method: "walk", Conditions: "radar-off, swarm-yes", MinLevel: 5, MaxLevel: 5, Chance: 30},
+		{Area: area, Version: "diamond", Method: "walk", MinLevel: 4, MaxLevel: 4, Chance: 5},
+	}
+	if actual := encounterSpots(encounters, "diamond"); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v but got %v", expected, actual)
+	}
+
+	if actual := expected[2].conditions(); actual != " (radar-off, swarm-yes)" {
+		t.Errorf("expected the swarm conditions listed, got %q", actual)
+	}
+	if actual := expected[3].conditions(); actual != "" {
+		t.Errorf("expected nothing listed without conditions, got %q", actual)
+	}
+}
+
+func TestGroupSpots(t *testing.T) {
+	var encounters []AreaEncounter
+	if err := json.Unmarshal([]byte(areaEncountersFixture), &encounters); err != nil {
+		t.Fatalf("could not decode fixture: %v", err)
+	}
+
+	versions, groups := groupSpots(encounterSpots(encounters, ""), spotSorts["area"], true)
+	if !reflect.DeepEqual(versions, []string{"red", "yellow"}) {
+		t.Errorf("expected red then yellow but got %v", versions)
+	}
+	if groups["red"][0].Area != "power-plant-area" {
+		t.Errorf("expected red sorted by area, got %v", groups["red"])
+	}
+
+	_, groups = groupSpots(encounterSpots(encounters, "red"), spotSorts["level"], true)
+	if groups["red"][0].Area != "viridian-forest-area" {
+		t.Errorf("expected red sorted by level, got %v", groups["red"])
+	}
+
+	_, groups = groupSpots(encounterSpots(encounters, "red"), spotSorts["chance"], true)
+	if groups["red"][0].Chance != 40 {
+		t.Errorf("expected red sorted by chance, got %v", groups["red"])
+	}
+
+	// A rare surf spot leads when grouped by method, but comes last across methods
+	surf := encounterSpot{Area: "seafoam-islands-1f", Version: "red", Method: "surf", MinLevel: 30, MaxLevel: 30, Chance: 1}
+	spots := append(encounterSpots(encounters, "red"), surf)
+
+	_, groups = groupSpots(spots, spotSorts["chance"], true)
+	if groups["red"][0] != surf {
+		t.Errorf("expected the surf group before the walk spots, got %v", groups["red"])
+	}
+	_, groups = groupSpots(spots, spotSorts["chance"], false)
+	if groups["red"][0].Chance != 40 || groups["red"][2] != surf {
+		t.Errorf("expected every spot sorted by chance, got %v", groups["red"])
+	}
+}